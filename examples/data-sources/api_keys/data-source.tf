@@ -0,0 +1,17 @@
+resource "resend_api_key" "ci" {
+  name = "ci"
+}
+
+data "resend_api_keys" "all" {}
+
+# Flag keys that are not managed by this configuration.
+check "unmanaged_api_keys" {
+  assert {
+    condition = alltrue([
+      for key in data.resend_api_keys.all.api_keys : contains([resend_api_key.ci.id], key.id)
+    ])
+    error_message = "Found API keys that are not managed by Terraform: ${join(", ", [
+      for key in data.resend_api_keys.all.api_keys : key.name if !contains([resend_api_key.ci.id], key.id)
+    ])}"
+  }
+}
@@ -0,0 +1,10 @@
+data "resend_domain" "example_com" {
+  name = "example.com"
+}
+
+# A sending key that can only be used with the domain.
+resource "resend_api_key" "example_com" {
+  name       = "example.com"
+  permission = "sending_access"
+  domain_id  = data.resend_domain.example_com.id
+}
@@ -0,0 +1,24 @@
+data "resend_domains" "all" {}
+
+# A sending key per domain.
+resource "resend_api_key" "sending" {
+  for_each = { for domain in data.resend_domains.all.domains : domain.name => domain.id }
+
+  name       = each.key
+  permission = "sending_access"
+  domain_id  = each.value
+}
+
+# Warn about domains that are not verified.
+check "domains_verified" {
+  assert {
+    condition     = alltrue([for domain in data.resend_domains.all.domains : domain.status == "verified"])
+    error_message = "Not all domains are verified."
+  }
+}
+
+data "resend_domains" "eu_example_com" {
+  region     = "eu-west-1"
+  status     = "verified"
+  name_regex = "\\.example\\.com$"
+}
@@ -0,0 +1,10 @@
+resource "aws_route53_record" "dmarc" {
+  zone_id = var.zone_id
+  name    = "_dmarc.example.com"
+  type    = "TXT"
+  ttl     = 3600
+  records = [provider::resend::dmarc_record("quarantine", "dmarc@example.com", 100, {
+    adkim = "s"
+    aspf  = "s"
+  })]
+}
@@ -0,0 +1,17 @@
+resource "resend_domain" "example_com" {
+  name = "example.com"
+}
+
+locals {
+  # The SPF record Resend requires on the send subdomain.
+  resend_spf = one([for record in resend_domain.example_com.records : record.value if record.record == "SPF" && record.type == "TXT"])
+}
+
+# Add Resend to the SPF record that is already published for Google Workspace.
+resource "aws_route53_record" "spf" {
+  zone_id = var.zone_id
+  name    = "send.example.com"
+  type    = "TXT"
+  ttl     = 3600
+  records = [provider::resend::merge_spf("v=spf1 include:_spf.google.com -all", local.resend_spf)]
+}
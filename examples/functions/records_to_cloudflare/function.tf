@@ -0,0 +1,18 @@
+resource "resend_domain" "example_com" {
+  name = "example.com"
+}
+
+locals {
+  cloudflare_records = jsondecode(provider::resend::records_to_cloudflare(resend_domain.example_com.records, "example.com"))
+}
+
+resource "cloudflare_record" "resend" {
+  for_each = { for record in local.cloudflare_records : "${record.type} ${record.name}" => record }
+
+  zone_id  = var.cloudflare_zone_id
+  type     = each.value.type
+  name     = each.value.name
+  content  = each.value.content
+  ttl      = each.value.ttl
+  priority = try(each.value.priority, null)
+}
@@ -0,0 +1,10 @@
+resource "resend_domain" "example_com" {
+  name = "example.com"
+}
+
+# Write the records to a change batch for
+# aws route53 change-resource-record-sets --change-batch file://resend.json
+resource "local_file" "change_batch" {
+  filename = "${path.module}/resend.json"
+  content  = provider::resend::records_to_route53_changes(resend_domain.example_com.records, "example.com")
+}
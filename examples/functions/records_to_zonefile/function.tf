@@ -0,0 +1,9 @@
+resource "resend_domain" "example_com" {
+  name = "example.com"
+}
+
+# Write the records to a zone file that can be imported by the DNS provider.
+resource "local_file" "zonefile" {
+  filename = "${path.module}/example.com.zone"
+  content  = provider::resend::records_to_zonefile(resend_domain.example_com.records, "example.com")
+}
@@ -1,3 +1,24 @@
+# The API key is read from the RESEND_API_KEY environment variable.
+provider "resend" {}
+
+# Read the API key from a file, e.g. one rendered by Vault Agent.
 provider "resend" {
-  # example configuration here
+  alias        = "file"
+  api_key_file = "/vault/secrets/resend"
+}
+
+# Read the API key with the 1Password CLI.
+provider "resend" {
+  alias           = "command"
+  api_key_command = ["op", "read", "op://Infrastructure/Resend/credential"]
+}
+
+# Read the API key and base URL of the "staging" profile from
+# ~/.config/resend/credentials:
+#
+#   [staging]
+#   api_key = "re_..."
+provider "resend" {
+  alias   = "staging"
+  profile = "staging"
 }
@@ -0,0 +1,3 @@
+# API keys can be imported by their ID or by their name.
+terraform import resend_api_key.my_key 8a5b6f2e-3c6d-4f1a-9e2b-7d4c1a0f9b3e
+terraform import resend_api_key.my_key name:Vercel-Web-App
@@ -0,0 +1,2 @@
+# Audiences can be imported by their ID.
+terraform import resend_audience.newsletter 78261eea-8f8b-4381-83c6-79fa7120f1cf
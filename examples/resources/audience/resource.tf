@@ -0,0 +1,3 @@
+resource "resend_audience" "newsletter" {
+  name = "Newsletter"
+}
@@ -0,0 +1,26 @@
+resource "resend_audience" "newsletter" {
+  name = "Newsletter"
+}
+
+# Sync the audience with a CSV file, e.g. an export of a CRM:
+#
+#   email,first_name,last_name
+#   jane@example.com,Jane,Doe
+resource "resend_audience_contacts" "newsletter" {
+  audience_id = resend_audience.newsletter.id
+  file        = "${path.module}/subscribers.csv"
+}
+
+resource "resend_audience" "team" {
+  name = "Team"
+}
+
+# Small audiences can be listed in the configuration. Unlike the contacts of a
+# file, they are stored in the state.
+resource "resend_audience_contacts" "team" {
+  audience_id = resend_audience.team.id
+  contacts = [
+    { email = "jane@example.com", first_name = "Jane" },
+    { email = "bob@example.com", first_name = "Bob" },
+  ]
+}
@@ -0,0 +1,2 @@
+# Broadcasts can be imported by their ID.
+terraform import resend_broadcast.release_2_0 559ac32e-9ef5-46fb-82a1-b76b840c0f7b
@@ -0,0 +1,17 @@
+resource "resend_audience" "customers" {
+  name = "Customers"
+}
+
+# The announcement is reviewed as a draft in the pull request that adds it and
+# sent by the pull request that sets send to true.
+resource "resend_broadcast" "release_2_0" {
+  audience_id = resend_audience.customers.id
+  name        = "Release 2.0"
+  from        = "Acme <news@example.com>"
+  reply_to    = ["support@example.com"]
+  subject     = "Acme 2.0 is here"
+  html        = file("${path.module}/release-2.0.html")
+
+  send         = true
+  scheduled_at = "2025-03-04T09:00:00Z"
+}
@@ -0,0 +1,2 @@
+# Contacts can be imported by the ID of their audience and their email.
+terraform import resend_contact.jane 78261eea-8f8b-4381-83c6-79fa7120f1cf/jane@example.com
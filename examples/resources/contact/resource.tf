@@ -0,0 +1,13 @@
+resource "resend_audience" "newsletter" {
+  name = "Newsletter"
+}
+
+resource "resend_contact" "jane" {
+  audience_id = resend_audience.newsletter.id
+  email       = "jane@example.com"
+  first_name  = "Jane"
+  last_name   = "Doe"
+
+  # Do not subscribe Jane again after she has unsubscribed.
+  keep_unsubscribed = true
+}
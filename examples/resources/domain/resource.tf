@@ -13,3 +13,11 @@ resource "resend_domain" "example_com" {
   name   = "example.com"
   region = "us-east-1"
 }
+
+resource "resend_domain" "tracked_example_com" {
+  name           = "tracked.example.com"
+  region         = "eu-west-1"
+  open_tracking  = true
+  click_tracking = true
+  tls            = "enforced"
+}
@@ -0,0 +1,28 @@
+terraform {
+  required_providers {
+    resend = {
+      source = "registry.terraform.io/chronark/resend"
+    }
+  }
+}
+
+provider "resend" {}
+
+
+resource "resend_domain" "example_com" {
+  name   = "example.com"
+  region = "us-east-1"
+}
+
+# Create the DNS records of resend_domain.example_com.records with your DNS
+# provider and let the verification depend on them.
+resource "resend_domain_verification" "example_com" {
+  domain_id = resend_domain.example_com.id
+  timeout   = "15m"
+}
+
+resource "resend_api_key" "example_com" {
+  name       = "example.com"
+  permission = "sending_access"
+  domain_id  = resend_domain_verification.example_com.domain_id
+}
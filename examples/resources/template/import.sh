@@ -0,0 +1,2 @@
+# Templates can be imported by their ID.
+terraform import resend_template.welcome 34a080c9-b17d-4187-ad80-5af20266e535
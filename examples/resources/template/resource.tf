@@ -0,0 +1,20 @@
+# The HTML is edited in the repository. Changes made in the dashboard show up
+# in the plan and are reverted by the next apply.
+resource "resend_template" "welcome" {
+  name      = "welcome"
+  subject   = "Welcome to Acme"
+  html      = file("${path.module}/templates/welcome.html")
+  published = true
+
+  variables = [
+    {
+      key            = "FIRST_NAME"
+      fallback_value = "there"
+    },
+    {
+      key            = "TRIAL_DAYS"
+      type           = "number"
+      fallback_value = "14"
+    },
+  ]
+}
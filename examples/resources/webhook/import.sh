@@ -0,0 +1,2 @@
+# Webhooks can be imported by their ID.
+terraform import resend_webhook.bounces 4dd369bc-aa82-4ff3-97de-514ae3000ee0
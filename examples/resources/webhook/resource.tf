@@ -0,0 +1,12 @@
+resource "resend_webhook" "bounces" {
+  endpoint = "https://events.example.com/webhooks/resend"
+  events   = ["email.bounced", "email.complained"]
+}
+
+# Pass the signing secret to the service that receives the events, so it can
+# verify them.
+resource "aws_ssm_parameter" "resend_webhook_secret" {
+  name  = "/events/resend-webhook-secret"
+  type  = "SecureString"
+  value = resend_webhook.bounces.signing_secret
+}
@@ -6,6 +6,7 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -14,7 +15,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"github.com/resendlabs/resend-go"
+	"github.com/resend/resend-go/v3"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -37,6 +38,7 @@ type ApiKeyResourceModel struct {
 	Name       types.String `tfsdk:"name"`
 	Permission types.String `tfsdk:"permission"`
 	DomainId   types.String `tfsdk:"domain_id"`
+	CreatedAt  types.String `tfsdk:"created_at"`
 }
 
 func (r *ApiKeyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -52,11 +54,24 @@ func (r *ApiKeyResource) Schema(ctx context.Context, req resource.SchemaRequest,
 			"id": schema.StringAttribute{
 				MarkdownDescription: "The API key ID",
 				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"token": schema.StringAttribute{
-				MarkdownDescription: "The API key token",
+				MarkdownDescription: "The API key token. It is only returned when the key is created and kept in the state afterwards.",
 				Computed:            true,
 				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "The date and time the API key was created",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"name": schema.StringAttribute{
 				MarkdownDescription: "The API key name",
@@ -106,6 +121,8 @@ func (r *ApiKeyResource) Configure(ctx context.Context, req resource.ConfigureRe
 }
 
 func (r *ApiKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withClientLogMasking(ctx, r.client)
+
 	var data ApiKeyResourceModel
 
 	// Read Terraform plan data into the model
@@ -115,7 +132,7 @@ func (r *ApiKeyResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	key, err := r.client.ApiKeys.Create(&resend.CreateApiKeyRequest{
+	key, err := r.client.ApiKeys.CreateWithContext(ctx, &resend.CreateApiKeyRequest{
 		Name:       data.Name.ValueString(),
 		Permission: data.Permission.ValueString(),
 		DomainId:   data.DomainId.ValueString(),
@@ -126,6 +143,15 @@ func (r *ApiKeyResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 	data.Id = types.StringValue(key.Id)
 	data.Token = types.StringValue(key.Token)
+	data.CreatedAt = types.StringNull()
+
+	// The creation time is not part of the create response.
+	keys, err := listApiKeys(ctx, r.client)
+	if err != nil {
+		resp.Diagnostics.AddWarning("Client Error", fmt.Sprintf("Unable to list keys to read the creation time, got error: %s", err))
+	} else if created, ok := findApiKey(keys, key.Id); ok {
+		data.CreatedAt = types.StringValue(created.CreatedAt)
+	}
 
 	// Write logs using the tflog package
 	// Documentation: https://terraform.io/plugin/log
@@ -136,6 +162,8 @@ func (r *ApiKeyResource) Create(ctx context.Context, req resource.CreateRequest,
 }
 
 func (r *ApiKeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withClientLogMasking(ctx, r.client)
+
 	var data ApiKeyResourceModel
 
 	// Read Terraform prior state data into the model
@@ -145,11 +173,34 @@ func (r *ApiKeyResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
+	// There is no endpoint to retrieve a single API key, so the key has to be
+	// looked up in the list of all keys.
+	keys, err := listApiKeys(ctx, r.client)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list keys, got error: %s", err))
+		return
+	}
+
+	key, ok := findApiKey(keys, data.Id.ValueString())
+	if !ok {
+		tflog.Warn(ctx, "key not found, removing from state", map[string]interface{}{
+			"id": data.Id.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// The token is never returned again, the value from the state is kept.
+	data.Name = types.StringValue(key.Name)
+	data.CreatedAt = types.StringValue(key.CreatedAt)
+
 	// Save updated data into Terraform state
-	//resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *ApiKeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withClientLogMasking(ctx, r.client)
+
 	var data ApiKeyResourceModel
 
 	// Read Terraform plan data into the model
@@ -172,6 +223,8 @@ func (r *ApiKeyResource) Update(ctx context.Context, req resource.UpdateRequest,
 }
 
 func (r *ApiKeyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withClientLogMasking(ctx, r.client)
+
 	var data ApiKeyResourceModel
 
 	// Read Terraform prior state data into the model
@@ -181,7 +234,11 @@ func (r *ApiKeyResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	_, err := r.client.ApiKeys.Remove(data.Id.ValueString())
+	_, err := r.client.ApiKeys.RemoveWithContext(ctx, data.Id.ValueString())
+	// The key has already been deleted outside of Terraform.
+	if isNotFound(err) {
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete key, got error: %s", err))
 		return
@@ -189,6 +246,100 @@ func (r *ApiKeyResource) Delete(ctx context.Context, req resource.DeleteRequest,
 
 }
 
+// listApiKeys returns all API keys of the account, following the pagination
+// of the list endpoint.
+func listApiKeys(ctx context.Context, client *resend.Client) ([]resend.ApiKey, error) {
+	var keys []resend.ApiKey
+	limit := 100
+	options := &resend.ListOptions{Limit: &limit}
+	for {
+		page, err := client.ApiKeys.ListWithOptions(ctx, options)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, page.Data...)
+
+		if !page.HasMore || len(page.Data) == 0 {
+			return keys, nil
+		}
+		after := page.Data[len(page.Data)-1].Id
+		options.After = &after
+	}
+}
+
+// findApiKey returns the key with the given ID.
+func findApiKey(keys []resend.ApiKey, id string) (resend.ApiKey, bool) {
+	for _, key := range keys {
+		if key.Id == id {
+			return key, true
+		}
+	}
+
+	return resend.ApiKey{}, false
+}
+
+// findApiKeyByName returns the key with the given name. Names are not unique,
+// so an error is returned if more than one key has the name.
+func findApiKeyByName(keys []resend.ApiKey, name string) (resend.ApiKey, bool, error) {
+	var matches []resend.ApiKey
+	for _, key := range keys {
+		if key.Name == name {
+			matches = append(matches, key)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return resend.ApiKey{}, false, nil
+	case 1:
+		return matches[0], true, nil
+	}
+
+	ids := make([]string, len(matches))
+	for i, key := range matches {
+		ids[i] = key.Id
+	}
+	return resend.ApiKey{}, false, fmt.Errorf("found %d keys named %q, import one of them by ID instead: %s", len(matches), name, strings.Join(ids, ", "))
+}
+
+// ImportState accepts either the ID of a key or its name in the form
+// name:<key name>.
 func (r *ApiKeyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	ctx = withClientLogMasking(ctx, r.client)
+
+	keys, err := listApiKeys(ctx, r.client)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list keys, got error: %s", err))
+		return
+	}
+
+	var key resend.ApiKey
+	var ok bool
+	if name, byName := strings.CutPrefix(req.ID, "name:"); byName {
+		key, ok, err = findApiKeyByName(keys, name)
+		if err != nil {
+			resp.Diagnostics.AddError("Ambiguous Import Identifier", err.Error())
+			return
+		}
+	} else {
+		key, ok = findApiKey(keys, req.ID)
+	}
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Cannot Import Non-Existent Key",
+			fmt.Sprintf("No API key matches %q. Use either the ID of the key or name:<key name>.", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), key.Id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), key.Name)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("created_at"), key.CreatedAt)...)
+
+	resp.Diagnostics.AddWarning(
+		"API Key Token Not Imported",
+		fmt.Sprintf("The token of API key %s is only returned when the key is created, so token is null for the imported key. "+
+			"The permission and domain_id of a key are not returned by the API either. "+
+			"Add them to the ignore_changes of the resource if they are configured, otherwise the key is replaced on the next apply.", key.Id),
+	)
 }
@@ -4,9 +4,12 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAccApiKeyResource(t *testing.T) {
+	var id string
+
 	resource.Test(t, resource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
@@ -21,13 +24,40 @@ resource "resend_api_key" "test" {
 					resource.TestCheckResourceAttr("resend_api_key.test", "name", "terraform"),
 					resource.TestCheckResourceAttrSet("resend_api_key.test", "id"),
 					resource.TestCheckResourceAttrSet("resend_api_key.test", "token"),
+					resource.TestCheckResourceAttrSet("resend_api_key.test", "created_at"),
+					testAccCaptureAttr("resend_api_key.test", "id", &id),
+				),
+			},
+			// Drift testing, the key is created again after it has been
+			// deleted outside of Terraform
+			{
+				PreConfig: func() {
+					_, err := testAccClient().ApiKeys.Remove(id)
+					require.NoError(t, err)
+				},
+				Config: providerConfig + `
+resource "resend_api_key" "test" {
+  name = "terraform"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckAttrChanged("resend_api_key.test", "id", &id),
 				),
 			},
 			// ImportState testing
 			{
-				ResourceName:      "resend_api_key.test",
-				ImportState:       true,
-				ImportStateVerify: false,
+				ResourceName:            "resend_api_key.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"token"},
+			},
+			// ImportState by name testing
+			{
+				ResourceName:            "resend_api_key.test",
+				ImportState:             true,
+				ImportStateId:           "name:terraform",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"token"},
 			},
 			// Delete testing automatically occurs in TestCase
 		},
@@ -0,0 +1,157 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/resend/resend-go/v3"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ApiKeysDataSource{}
+
+func NewApiKeysDataSource() datasource.DataSource {
+	return &ApiKeysDataSource{}
+}
+
+// ApiKeysDataSource defines the data source implementation.
+type ApiKeysDataSource struct {
+	client *resend.Client
+}
+
+// ApiKeysDataSourceModel describes the data source data model.
+type ApiKeysDataSourceModel struct {
+	NameRegex types.String `tfsdk:"name_regex"`
+	ApiKeys   types.List   `tfsdk:"api_keys"`
+}
+
+// ApiKeySummary describes a key in the list of resend_api_keys.
+type ApiKeySummary struct {
+	Id        types.String `tfsdk:"id"`
+	Name      types.String `tfsdk:"name"`
+	CreatedAt types.String `tfsdk:"created_at"`
+}
+
+// apiKeySummaryAttrTypes are the attribute types of an ApiKeySummary.
+var apiKeySummaryAttrTypes = map[string]attr.Type{
+	"id":         types.StringType,
+	"name":       types.StringType,
+	"created_at": types.StringType,
+}
+
+func (d *ApiKeysDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_keys"
+}
+
+func (d *ApiKeysDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "List the API keys of the account, e.g. to audit keys that are not managed by Terraform. The tokens of the keys cannot be read.",
+
+		Attributes: map[string]schema.Attribute{
+			"name_regex": schema.StringAttribute{
+				MarkdownDescription: "Only list keys whose name matches this [regular expression](https://github.com/google/re2/wiki/Syntax), e.g. `^ci-`.",
+				Optional:            true,
+				Validators: []validator.String{
+					regexValidator{},
+				},
+			},
+			"api_keys": schema.ListNestedAttribute{
+				MarkdownDescription: "The matching API keys, newest first.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The unique identifier of the key within Resend.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the key.",
+							Computed:            true,
+						},
+						"created_at": schema.StringAttribute{
+							MarkdownDescription: "The date and time the key was created.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ApiKeysDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*resend.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *resend.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *ApiKeysDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withClientLogMasking(ctx, d.client)
+
+	var data ApiKeysDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// name_regex has been checked by regexValidator.
+	var nameRegex *regexp.Regexp
+	if !data.NameRegex.IsNull() {
+		nameRegex = regexp.MustCompile(data.NameRegex.ValueString())
+	}
+
+	keys, err := listApiKeys(ctx, d.client)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list keys, got error: %s", err))
+		return
+	}
+
+	summaries := []ApiKeySummary{}
+	for _, key := range keys {
+		if nameRegex != nil && !nameRegex.MatchString(key.Name) {
+			continue
+		}
+
+		summaries = append(summaries, ApiKeySummary{
+			Id:        types.StringValue(key.Id),
+			Name:      types.StringValue(key.Name),
+			CreatedAt: types.StringValue(key.CreatedAt),
+		})
+	}
+
+	list, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: apiKeySummaryAttrTypes}, summaries)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.ApiKeys = list
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccApiKeysDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+data "resend_api_keys" "test" {
+  name_regex = "("
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Regular Expression`),
+			},
+			// Read testing
+			{
+				Config: providerConfig + `
+resource "resend_api_key" "ci" {
+  name = "terraform-list-ci"
+}
+
+resource "resend_api_key" "deploy" {
+  name = "terraform-list-deploy"
+}
+
+data "resend_api_keys" "all" {
+  name_regex = "^terraform-list-"
+
+  depends_on = [resend_api_key.ci, resend_api_key.deploy]
+}
+
+data "resend_api_keys" "ci" {
+  name_regex = "^terraform-list-ci$"
+
+  depends_on = [resend_api_key.ci, resend_api_key.deploy]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.resend_api_keys.all", "api_keys.#", "2"),
+					resource.TestCheckTypeSetElemAttrPair("data.resend_api_keys.all", "api_keys.*.id", "resend_api_key.ci", "id"),
+					resource.TestCheckTypeSetElemAttrPair("data.resend_api_keys.all", "api_keys.*.id", "resend_api_key.deploy", "id"),
+					resource.TestCheckResourceAttr("data.resend_api_keys.ci", "api_keys.#", "1"),
+					resource.TestCheckResourceAttrPair("data.resend_api_keys.ci", "api_keys.0.id", "resend_api_key.ci", "id"),
+					resource.TestCheckResourceAttr("data.resend_api_keys.ci", "api_keys.0.name", "terraform-list-ci"),
+					resource.TestCheckResourceAttrPair("data.resend_api_keys.ci", "api_keys.0.created_at", "resend_api_key.ci", "created_at"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,467 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/resend/resend-go/v3"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &AudienceContactsResource{}
+var _ resource.ResourceWithModifyPlan = &AudienceContactsResource{}
+var _ resource.ResourceWithValidateConfig = &AudienceContactsResource{}
+
+// inlineContactsLimit is the number of contacts in the contacts attribute
+// above which a warning recommends the file attribute instead.
+const inlineContactsLimit = 100
+
+func NewAudienceContactsResource() resource.Resource {
+	return &AudienceContactsResource{}
+}
+
+// AudienceContactsResource defines the resource implementation. It manages
+// all contacts of an audience at once. Contacts read from a file are only
+// kept in the state as a hash, contacts of the configuration are stored in
+// the state like any other attribute.
+type AudienceContactsResource struct {
+	client *resend.Client
+}
+
+// AudienceContactsResourceModel describes the resource data model.
+type AudienceContactsResourceModel struct {
+	Id           types.String `tfsdk:"id"`
+	AudienceId   types.String `tfsdk:"audience_id"`
+	Contacts     types.Set    `tfsdk:"contacts"`
+	File         types.String `tfsdk:"file"`
+	ContentHash  types.String `tfsdk:"content_hash"`
+	ContactCount types.Int64  `tfsdk:"contact_count"`
+	Added        types.Int64  `tfsdk:"added"`
+	Updated      types.Int64  `tfsdk:"updated"`
+	Removed      types.Int64  `tfsdk:"removed"`
+}
+
+// AudienceContact is an element of the contacts of resend_audience_contacts.
+type AudienceContact struct {
+	Email        types.String `tfsdk:"email"`
+	FirstName    types.String `tfsdk:"first_name"`
+	LastName     types.String `tfsdk:"last_name"`
+	Unsubscribed types.Bool   `tfsdk:"unsubscribed"`
+}
+
+// audienceContactAttrTypes are the attribute types of an AudienceContact.
+var audienceContactAttrTypes = map[string]attr.Type{
+	"email":        types.StringType,
+	"first_name":   types.StringType,
+	"last_name":    types.StringType,
+	"unsubscribed": types.BoolType,
+}
+
+func (r *AudienceContactsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_audience_contacts"
+}
+
+func (r *AudienceContactsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manage all contacts of an audience from a list or a CSV or JSON file. " +
+			"Contacts are added, updated and removed so that the audience has exactly the given contacts. " +
+			"Contacts listed in `contacts` are stored in the state like the rest of the configuration. " +
+			"Of the contacts of `file` only a hash is kept in the state, which makes `file` suitable for thousands of contacts. " +
+			"Do not use it together with `resend_contact` for the same audience.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the audience.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"audience_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the audience whose contacts are managed.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"contacts": schema.SetNestedAttribute{
+				MarkdownDescription: "The contacts of the audience. Exactly one of `contacts` and `file` must be set. " +
+					"The contacts, including names and email addresses, are stored in plain text in the state. " +
+					"Use `file` for audiences with more than a few contacts, a warning is shown above 100 contacts.",
+				Optional: true,
+				Validators: []validator.Set{
+					setvalidator.ExactlyOneOf(path.MatchRoot("contacts"), path.MatchRoot("file")),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"email": schema.StringAttribute{
+							MarkdownDescription: "The email address of the contact.",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"first_name": schema.StringAttribute{
+							MarkdownDescription: "The first name of the contact.",
+							Optional:            true,
+						},
+						"last_name": schema.StringAttribute{
+							MarkdownDescription: "The last name of the contact.",
+							Optional:            true,
+						},
+						"unsubscribed": schema.BoolAttribute{
+							MarkdownDescription: "Whether the contact is unsubscribed from broadcasts. If not set, new contacts are subscribed and the subscription of existing contacts is left to the recipient.",
+							Optional:            true,
+						},
+					},
+				},
+			},
+			"file": schema.StringAttribute{
+				MarkdownDescription: "The path of a CSV or JSON file with the contacts of the audience, which must exist when Terraform plans. Exactly one of `contacts` and `file` must be set.\n" +
+					"  - **CSV** files need a header row with an `email` column and can have `first_name`, `last_name` and `unsubscribed` columns. Other columns are ignored, so exports of Resend can be used.\n" +
+					"  - **JSON** files contain an array of objects with the attributes of `contacts`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"content_hash": schema.StringAttribute{
+				MarkdownDescription: "A hash of the contacts. It changes when the contacts of the configuration or the file change, or when the contacts of the audience are changed outside of Terraform.",
+				Computed:            true,
+			},
+			"contact_count": schema.Int64Attribute{
+				MarkdownDescription: "The number of contacts in the audience.",
+				Computed:            true,
+			},
+			"added": schema.Int64Attribute{
+				MarkdownDescription: "The number of contacts added by the last apply.",
+				Computed:            true,
+			},
+			"updated": schema.Int64Attribute{
+				MarkdownDescription: "The number of contacts updated by the last apply.",
+				Computed:            true,
+			},
+			"removed": schema.Int64Attribute{
+				MarkdownDescription: "The number of contacts removed by the last apply.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *AudienceContactsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*resend.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *resend.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// desiredContacts returns the contacts of the model, from either the contacts
+// attribute or the file. Errors are added to diags.
+func desiredContacts(ctx context.Context, data AudienceContactsResourceModel, diags *diag.Diagnostics) []syncContact {
+	if !data.File.IsNull() {
+		contacts, err := readContactsFile(data.File.ValueString())
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("file"),
+				"Unable to read contacts file",
+				fmt.Sprintf("The provider cannot read the contacts from %q, got error: %s", data.File.ValueString(), err),
+			)
+		}
+		return contacts
+	}
+
+	var elements []AudienceContact
+	diags.Append(data.Contacts.ElementsAs(ctx, &elements, false)...)
+	if diags.HasError() {
+		return nil
+	}
+
+	contacts := make([]syncContact, len(elements))
+	for i, e := range elements {
+		contacts[i] = syncContact{
+			Email:        e.Email.ValueString(),
+			FirstName:    e.FirstName.ValueString(),
+			LastName:     e.LastName.ValueString(),
+			Unsubscribed: e.Unsubscribed.ValueBoolPointer(),
+		}
+	}
+	if err := checkContacts(contacts); err != nil {
+		diags.AddAttributeError(path.Root("contacts"), "Invalid Contacts", err.Error())
+	}
+
+	return contacts
+}
+
+func (r *AudienceContactsResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	ctx = withClientLogMasking(ctx, r.client)
+
+	var data AudienceContactsResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if count := len(data.Contacts.Elements()); count > inlineContactsLimit {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("contacts"),
+			"Large Contact List",
+			fmt.Sprintf("The %d contacts are stored in the state in full and make every plan slower. "+
+				"Use the file attribute for more than %d contacts, of which only a hash is stored in the state.", count, inlineContactsLimit),
+		)
+	}
+}
+
+func (r *AudienceContactsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	ctx = withClientLogMasking(ctx, r.client)
+
+	// Nothing to plan when the resource is destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan AudienceContactsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The contacts are only known during apply, e.g. when the file is
+	// written by another resource.
+	if plan.File.IsUnknown() || isSetUnknown(plan.Contacts) {
+		return
+	}
+
+	contacts := desiredContacts(ctx, plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A changed file does not change the configuration, so the hash makes
+	// Terraform update the resource. Read sets the hash of the audience if
+	// its contacts differ from the desired ones.
+	hash := hashContacts(contacts)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("content_hash"), hash)...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("contact_count"), int64(len(contacts)))...)
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var state AudienceContactsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.ContentHash.ValueString() == hash {
+		return
+	}
+
+	// Terraform only marks the counts as unknown if the configuration has
+	// changed, not if just the file or the audience did.
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("added"), types.Int64Unknown())...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("updated"), types.Int64Unknown())...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("removed"), types.Int64Unknown())...)
+}
+
+func (r *AudienceContactsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withClientLogMasking(ctx, r.client)
+
+	var data AudienceContactsResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Id = data.AudienceId
+	r.sync(ctx, &data, &resp.Diagnostics)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AudienceContactsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withClientLogMasking(ctx, r.client)
+
+	var data AudienceContactsResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	existing, err := listContacts(ctx, r.client, data.AudienceId.ValueString())
+	if isNotFound(err) {
+		tflog.Warn(ctx, "audience not found, removing contacts from state", map[string]interface{}{
+			"audience_id": data.AudienceId.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list contacts, got error: %s", err))
+		return
+	}
+	data.ContactCount = types.Int64Value(int64(len(existing)))
+
+	// The desired contacts are needed to tell whether the audience has
+	// changed, as contacts without unsubscribed may have either status. If
+	// the file can no longer be read, the plan reports the error.
+	var diags diag.Diagnostics
+	contacts := desiredContacts(ctx, data, &diags)
+	if !diags.HasError() && diffContacts(contacts, existing).empty() {
+		data.ContentHash = types.StringValue(hashContacts(contacts))
+	} else {
+		data.ContentHash = types.StringValue(hashExistingContacts(existing))
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AudienceContactsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withClientLogMasking(ctx, r.client)
+
+	var data AudienceContactsResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.sync(ctx, &data, &resp.Diagnostics)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// sync brings the contacts of the audience in line with the model and sets
+// its computed attributes. Errors are added to diags.
+func (r *AudienceContactsResource) sync(ctx context.Context, data *AudienceContactsResourceModel, diags *diag.Diagnostics) {
+	contacts := desiredContacts(ctx, *data, diags)
+	if diags.HasError() {
+		return
+	}
+
+	existing, err := listContacts(ctx, r.client, data.AudienceId.ValueString())
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to list contacts, got error: %s", err))
+		return
+	}
+
+	plan := diffContacts(contacts, existing)
+	tflog.Info(ctx, "Synchronizing audience contacts", map[string]interface{}{
+		"audience_id": data.AudienceId.ValueString(),
+		"add":         len(plan.Add),
+		"update":      len(plan.Update),
+		"remove":      len(plan.Remove),
+	})
+
+	data.ContentHash = types.StringValue(hashContacts(contacts))
+	data.ContactCount = types.Int64Value(int64(len(contacts)))
+	data.Added = types.Int64Value(int64(len(plan.Add)))
+	data.Updated = types.Int64Value(int64(len(plan.Update)))
+	data.Removed = types.Int64Value(int64(len(plan.Remove)))
+
+	// The state is saved even if some changes failed, the next refresh
+	// finds the remaining differences.
+	err = applyContactSync(ctx, r.client, data.AudienceId.ValueString(), plan)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to synchronize the contacts of the audience, got error: %s", err))
+	}
+}
+
+func (r *AudienceContactsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withClientLogMasking(ctx, r.client)
+
+	var data AudienceContactsResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	existing, err := listContacts(ctx, r.client, data.AudienceId.ValueString())
+	// The audience has already been deleted, together with its contacts.
+	if isNotFound(err) {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list contacts, got error: %s", err))
+		return
+	}
+
+	err = applyContactSync(ctx, r.client, data.AudienceId.ValueString(), contactSyncPlan{Remove: existing})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove the contacts of the audience, got error: %s", err))
+		return
+	}
+}
+
+// isSetUnknown reports whether a set or any of its elements is unknown.
+func isSetUnknown(value types.Set) bool {
+	if value.IsUnknown() {
+		return true
+	}
+
+	for _, element := range value.Elements() {
+		object, ok := element.(types.Object)
+		if !ok {
+			return element.IsUnknown()
+		}
+		if object.IsUnknown() {
+			return true
+		}
+		for _, attribute := range object.Attributes() {
+			if attribute.IsUnknown() {
+				return true
+			}
+		}
+	}
+
+	return false
+}
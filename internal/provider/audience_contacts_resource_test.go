@@ -0,0 +1,219 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/resend/resend-go/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func testAccAudienceContactsConfig(attributes string) string {
+	return providerConfig + fmt.Sprintf(`
+resource "resend_audience" "test" {
+  name = "bulk"
+}
+
+resource "resend_audience_contacts" "test" {
+  audience_id = resend_audience.test.id
+%s
+}
+`, attributes)
+}
+
+// testAccWriteContactsCsv writes a CSV file with jane@example.com and the
+// contacts user000@example.com to user<n-1>@example.com.
+func testAccWriteContactsCsv(t *testing.T, filename string, n int) func() {
+	return func() {
+		lines := []string{"email,first_name,last_name", "jane@example.com,Jane,Doe"}
+		for i := range n {
+			lines = append(lines, fmt.Sprintf("user%03d@example.com,User,%d", i, i))
+		}
+		require.NoError(t, os.WriteFile(filename, []byte(strings.Join(lines, "\n")+"\n"), 0o600))
+	}
+}
+
+// testAccCheckAudienceContacts checks the number of contacts in the audience.
+func testAccCheckAudienceContacts(audienceId *string, count int) func(*terraform.State) error {
+	return func(s *terraform.State) error {
+		contacts, err := listContacts(context.Background(), testAccClient(), *audienceId)
+		if err != nil {
+			return err
+		}
+		if len(contacts) != count {
+			return fmt.Errorf("expected %d contacts in the audience, got %d", count, len(contacts))
+		}
+		return nil
+	}
+}
+
+func TestAccAudienceContactsResource(t *testing.T) {
+	var audienceId string
+	filename := filepath.Join(t.TempDir(), "contacts.csv")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Emails are unique regardless of their case
+			{
+				Config: testAccAudienceContactsConfig(`
+  contacts = [
+    { email = "jane@example.com" },
+    { email = "Jane@example.com" },
+  ]
+`),
+				ExpectError: regexp.MustCompile(`the email (?i:jane@example.com) is used by more than one contact`),
+			},
+			// Create and Read testing
+			{
+				Config: testAccAudienceContactsConfig(`
+  contacts = [
+    { email = "jane@example.com", first_name = "Jane" },
+    { email = "bob@example.com", unsubscribed = true },
+  ]
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("resend_audience_contacts.test", "id", "resend_audience.test", "id"),
+					resource.TestCheckResourceAttr("resend_audience_contacts.test", "contact_count", "2"),
+					resource.TestCheckResourceAttr("resend_audience_contacts.test", "added", "2"),
+					resource.TestCheckResourceAttr("resend_audience_contacts.test", "updated", "0"),
+					resource.TestCheckResourceAttr("resend_audience_contacts.test", "removed", "0"),
+					resource.TestCheckResourceAttrSet("resend_audience_contacts.test", "content_hash"),
+					testAccCaptureAttr("resend_audience_contacts.test", "audience_id", &audienceId),
+					func(s *terraform.State) error {
+						contact, err := testAccClient().Contacts.Get(&resend.GetContactOptions{AudienceId: audienceId, Id: "bob@example.com"})
+						if err != nil {
+							return err
+						}
+						if !contact.Unsubscribed {
+							return fmt.Errorf("expected bob@example.com to be unsubscribed")
+						}
+						return nil
+					},
+				),
+			},
+			// Switching to a file updates Jane, removes Bob and adds more
+			// contacts than fit on a page
+			{
+				PreConfig: testAccWriteContactsCsv(t, filename, 149),
+				Config: testAccAudienceContactsConfig(fmt.Sprintf(`
+  file = %q
+`, filename)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("resend_audience_contacts.test", "contact_count", "150"),
+					resource.TestCheckResourceAttr("resend_audience_contacts.test", "added", "149"),
+					resource.TestCheckResourceAttr("resend_audience_contacts.test", "updated", "1"),
+					resource.TestCheckResourceAttr("resend_audience_contacts.test", "removed", "1"),
+					testAccCheckAudienceContacts(&audienceId, 150),
+				),
+			},
+			// Changes outside of Terraform are reverted
+			{
+				PreConfig: func() {
+					client := testAccClient()
+					_, err := client.Contacts.Remove(&resend.RemoveContactOptions{AudienceId: audienceId, Id: "user000@example.com"})
+					require.NoError(t, err)
+					_, err = client.Contacts.Create(&resend.CreateContactRequest{AudienceId: audienceId, Email: "stray@example.com"})
+					require.NoError(t, err)
+				},
+				Config: testAccAudienceContactsConfig(fmt.Sprintf(`
+  file = %q
+`, filename)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("resend_audience_contacts.test", "added", "1"),
+					resource.TestCheckResourceAttr("resend_audience_contacts.test", "updated", "0"),
+					resource.TestCheckResourceAttr("resend_audience_contacts.test", "removed", "1"),
+					testAccCheckAudienceContacts(&audienceId, 150),
+				),
+			},
+			// Recipients that unsubscribe are not a change, as the file does
+			// not set unsubscribed
+			{
+				PreConfig: func() {
+					params := &resend.UpdateContactRequest{AudienceId: audienceId, Id: "user001@example.com"}
+					params.SetUnsubscribed(true)
+					_, err := testAccClient().Contacts.Update(params)
+					require.NoError(t, err)
+				},
+				Config: testAccAudienceContactsConfig(fmt.Sprintf(`
+  file = %q
+`, filename)),
+				PlanOnly: true,
+			},
+			// A changed file is applied although the configuration is the same
+			{
+				PreConfig: testAccWriteContactsCsv(t, filename, 99),
+				Config: testAccAudienceContactsConfig(fmt.Sprintf(`
+  file = %q
+`, filename)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("resend_audience_contacts.test", "contact_count", "100"),
+					resource.TestCheckResourceAttr("resend_audience_contacts.test", "added", "0"),
+					resource.TestCheckResourceAttr("resend_audience_contacts.test", "updated", "0"),
+					resource.TestCheckResourceAttr("resend_audience_contacts.test", "removed", "50"),
+					testAccCheckAudienceContacts(&audienceId, 100),
+				),
+			},
+			// Destroying the resource removes the contacts from the audience
+			{
+				Config: providerConfig + `
+resource "resend_audience" "test" {
+  name = "bulk"
+}
+`,
+				Check: testAccCheckAudienceContacts(&audienceId, 0),
+			},
+		},
+	})
+}
+
+func TestAudienceContactsResourceLargeListWarning(t *testing.T) {
+	ctx := context.Background()
+	r := &AudienceContactsResource{}
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+
+	validate := func(n int) diag.Diagnostics {
+		contacts := make([]attr.Value, n)
+		for i := range contacts {
+			contacts[i] = types.ObjectValueMust(audienceContactAttrTypes, map[string]attr.Value{
+				"email":        types.StringValue(fmt.Sprintf("user%03d@example.com", i)),
+				"first_name":   types.StringNull(),
+				"last_name":    types.StringNull(),
+				"unsubscribed": types.BoolNull(),
+			})
+		}
+		config := tfsdk.State{Schema: schemaResp.Schema}
+		diags := config.Set(ctx, &AudienceContactsResourceModel{
+			AudienceId: types.StringValue("audience"),
+			Contacts:   types.SetValueMust(types.ObjectType{AttrTypes: audienceContactAttrTypes}, contacts),
+		})
+		require.False(t, diags.HasError(), diags)
+
+		var resp fwresource.ValidateConfigResponse
+		r.ValidateConfig(ctx, fwresource.ValidateConfigRequest{
+			Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config.Raw},
+		}, &resp)
+		return resp.Diagnostics
+	}
+
+	require.Empty(t, validate(inlineContactsLimit))
+
+	diags := validate(inlineContactsLimit + 1)
+	require.Len(t, diags, 1)
+	require.Equal(t, diag.SeverityWarning, diags[0].Severity())
+	require.Contains(t, diags[0].Detail(), "Use the file attribute")
+}
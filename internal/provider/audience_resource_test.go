@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccAudienceResource(t *testing.T) {
+	var id string
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "resend_audience" "test" {
+  name = "terraform"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("resend_audience.test", "name", "terraform"),
+					resource.TestCheckResourceAttrSet("resend_audience.test", "id"),
+					resource.TestCheckResourceAttrSet("resend_audience.test", "created_at"),
+					testAccCaptureAttr("resend_audience.test", "id", &id),
+				),
+			},
+			// Replace testing, audiences cannot be renamed
+			{
+				Config: providerConfig + `
+resource "resend_audience" "test" {
+  name = "terraform-renamed"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("resend_audience.test", "name", "terraform-renamed"),
+					testAccCheckAttrChanged("resend_audience.test", "id", &id),
+					testAccCaptureAttr("resend_audience.test", "id", &id),
+				),
+			},
+			// Drift testing, the audience is created again after it has been
+			// deleted outside of Terraform
+			{
+				PreConfig: func() {
+					_, err := testAccClient().Segments.Remove(id)
+					require.NoError(t, err)
+				},
+				Config: providerConfig + `
+resource "resend_audience" "test" {
+  name = "terraform-renamed"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckAttrChanged("resend_audience.test", "id", &id),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "resend_audience.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
@@ -0,0 +1,534 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/resend/resend-go/v3"
+)
+
+const (
+	// broadcastStatusDraft is the status of broadcasts that have not been
+	// sent or scheduled. Only drafts can be changed.
+	broadcastStatusDraft = "draft"
+	// broadcastStatusScheduled is the status of broadcasts that are sent
+	// later. They can be cancelled by deleting them.
+	broadcastStatusScheduled = "scheduled"
+)
+
+// broadcastVariables are the variables that Resend fills from the contact a
+// broadcast is sent to.
+var broadcastVariables = []string{"FIRST_NAME", "LAST_NAME", "EMAIL", "RESEND_UNSUBSCRIBE_URL"}
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &BroadcastResource{}
+var _ resource.ResourceWithImportState = &BroadcastResource{}
+var _ resource.ResourceWithModifyPlan = &BroadcastResource{}
+var _ resource.ResourceWithValidateConfig = &BroadcastResource{}
+
+func NewBroadcastResource() resource.Resource {
+	return &BroadcastResource{}
+}
+
+// BroadcastResource defines the resource implementation.
+type BroadcastResource struct {
+	client *resend.Client
+}
+
+// BroadcastResourceModel describes the resource data model.
+type BroadcastResourceModel struct {
+	Id          types.String `tfsdk:"id"`
+	AudienceId  types.String `tfsdk:"audience_id"`
+	From        types.String `tfsdk:"from"`
+	Subject     types.String `tfsdk:"subject"`
+	Html        types.String `tfsdk:"html"`
+	Text        types.String `tfsdk:"text"`
+	ReplyTo     types.List   `tfsdk:"reply_to"`
+	Name        types.String `tfsdk:"name"`
+	ScheduledAt types.String `tfsdk:"scheduled_at"`
+	Send        types.Bool   `tfsdk:"send"`
+	Status      types.String `tfsdk:"status"`
+	CreatedAt   types.String `tfsdk:"created_at"`
+}
+
+// updateBroadcastRequest is the body of a broadcast update. It is used instead
+// of resend.UpdateBroadcastRequest, which drops empty attributes and so can
+// never remove the text, name or reply-to addresses of a broadcast.
+type updateBroadcastRequest struct {
+	SegmentId string   `json:"segment_id"`
+	From      string   `json:"from"`
+	Subject   string   `json:"subject"`
+	Html      string   `json:"html"`
+	Text      string   `json:"text"`
+	ReplyTo   []string `json:"reply_to"`
+	Name      string   `json:"name"`
+}
+
+func (r *BroadcastResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_broadcast"
+}
+
+func (r *BroadcastResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "A broadcast, an email sent to all contacts of an audience. " +
+			"The broadcast is created as a draft and sent once `send` is `true`, e.g. when a pull request that reviewed it is merged. " +
+			"Sent and scheduled broadcasts can no longer be changed. " +
+			"Destroying a draft deletes it, destroying a scheduled broadcast cancels it and sent broadcasts are kept in Resend.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The unique identifier of the broadcast within Resend.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"audience_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the audience the broadcast is sent to.",
+				Required:            true,
+			},
+			"from": schema.StringAttribute{
+				MarkdownDescription: "The sender, e.g. `Acme <news@example.com>`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"subject": schema.StringAttribute{
+				MarkdownDescription: "The subject of the email.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"html": schema.StringAttribute{
+				MarkdownDescription: "The HTML version of the email. At least one of `html` and `text` must be set. " +
+					"Properties of the contact are inserted with e.g. `{{{FIRST_NAME|there}}}`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.AtLeastOneOf(path.MatchRoot("html"), path.MatchRoot("text")),
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"text": schema.StringAttribute{
+				MarkdownDescription: "The plain text version of the email. At least one of `html` and `text` must be set.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"reply_to": schema.ListAttribute{
+				MarkdownDescription: "The addresses replies are sent to.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the broadcast in the Resend dashboard.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"scheduled_at": schema.StringAttribute{
+				MarkdownDescription: "When the broadcast is sent once `send` is `true`, either in ISO 8601 format, e.g. `2024-08-05T11:52:01.858Z`, or in natural language, e.g. `in 1 hour`. It is sent right away if not set.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"send": schema.BoolAttribute{
+				MarkdownDescription: "Send the broadcast, or schedule it if `scheduled_at` is set. Defaults to `false`, which keeps the broadcast a draft. " +
+					"Sent and scheduled broadcasts cannot be turned back into drafts, destroy a scheduled broadcast to cancel it.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "The status of the broadcast, e.g. `draft`, `scheduled`, `queued` or `sent`.",
+				Computed:            true,
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "The date and time the broadcast was created.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *BroadcastResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*resend.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *resend.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *BroadcastResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	ctx = withClientLogMasking(ctx, r.client)
+
+	var data BroadcastResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Contacts can have custom properties that are managed outside of
+	// Terraform, so other variables are only warned about.
+	content := []struct {
+		name  string
+		value types.String
+	}{{"subject", data.Subject}, {"html", data.Html}, {"text", data.Text}}
+	for _, c := range content {
+		for _, p := range placeholdersOf(path.Root(c.name), c.value, &resp.Diagnostics) {
+			if !slices.Contains(broadcastVariables, p.Key) {
+				resp.Diagnostics.AddAttributeWarning(
+					path.Root(c.name),
+					"Unknown Variable",
+					fmt.Sprintf("Line %d: the variable %s is not one of %s. Unless it is a custom property of the contacts, it will be empty in the email.",
+						p.Line, p.Key, strings.Join(broadcastVariables, ", ")),
+				)
+			}
+		}
+	}
+}
+
+func (r *BroadcastResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	ctx = withClientLogMasking(ctx, r.client)
+
+	// Only existing broadcasts that are updated can have been sent.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state BroadcastResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.Status.ValueString() == broadcastStatusDraft {
+		return
+	}
+
+	// A scheduled broadcast is only cancelled by deleting it and a sent
+	// broadcast cannot be taken back, so neither can become a draft again.
+	if state.Send.ValueBool() && !plan.Send.IsUnknown() && !plan.Send.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("send"),
+			"Broadcast Already Sent",
+			fmt.Sprintf("Broadcast %s has status %s and cannot be turned back into a draft. Destroy it to cancel a scheduled broadcast, or replace it with terraform apply -replace.",
+				state.Id.ValueString(), state.Status.ValueString()),
+		)
+	}
+
+	// Sending a broadcast that has already been sent, e.g. from the
+	// dashboard, does nothing.
+	changes := []struct {
+		name    string
+		changed bool
+	}{
+		{"audience_id", !plan.AudienceId.Equal(state.AudienceId)},
+		{"from", !plan.From.Equal(state.From)},
+		{"subject", !plan.Subject.Equal(state.Subject)},
+		{"html", !plan.Html.Equal(state.Html)},
+		{"text", !plan.Text.Equal(state.Text)},
+		{"reply_to", !plan.ReplyTo.Equal(state.ReplyTo)},
+		{"name", !plan.Name.Equal(state.Name)},
+		{"scheduled_at", !plan.ScheduledAt.Equal(state.ScheduledAt)},
+	}
+	for _, change := range changes {
+		if change.changed {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(change.name),
+				"Broadcast Already Sent",
+				fmt.Sprintf("Broadcast %s has status %s, only drafts can be changed. Create a new broadcast instead, e.g. by replacing it with terraform apply -replace.",
+					state.Id.ValueString(), state.Status.ValueString()),
+			)
+		}
+	}
+}
+
+func (r *BroadcastResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withClientLogMasking(ctx, r.client)
+
+	var data BroadcastResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var replyTo []string
+	resp.Diagnostics.Append(data.ReplyTo.ElementsAs(ctx, &replyTo, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	broadcast, err := r.client.Broadcasts.CreateWithContext(ctx, &resend.CreateBroadcastRequest{
+		SegmentId: data.AudienceId.ValueString(),
+		From:      data.From.ValueString(),
+		Subject:   data.Subject.ValueString(),
+		Html:      data.Html.ValueString(),
+		Text:      data.Text.ValueString(),
+		ReplyTo:   replyTo,
+		Name:      data.Name.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create broadcast, got error: %s", err))
+		return
+	}
+	data.Id = types.StringValue(broadcast.Id)
+
+	// The broadcast is sent separately. If sending fails, the error taints
+	// the new draft, so the next apply deletes it and creates and sends a
+	// new broadcast.
+	if data.Send.ValueBool() {
+		r.send(ctx, &data, &resp.Diagnostics)
+	}
+	r.readStatus(ctx, &data, &resp.Diagnostics)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BroadcastResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withClientLogMasking(ctx, r.client)
+
+	var data BroadcastResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	broadcast, err := r.client.Broadcasts.GetWithContext(ctx, data.Id.ValueString())
+	if isNotFound(err) {
+		tflog.Warn(ctx, "broadcast not found, removing from state", map[string]interface{}{
+			"id": data.Id.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read broadcast, got error: %s", err))
+		return
+	}
+
+	data.AudienceId = types.StringValue(broadcast.SegmentId)
+	if broadcast.SegmentId == "" {
+		data.AudienceId = types.StringValue(broadcast.AudienceId)
+	}
+	data.From = types.StringValue(broadcast.From)
+	data.Subject = types.StringValue(broadcast.Subject)
+	data.Html = stringOrNull(broadcast.Html)
+	data.Text = stringOrNull(broadcast.Text)
+	data.Name = stringOrNull(broadcast.Name)
+	data.Status = types.StringValue(broadcast.Status)
+	data.CreatedAt = types.StringValue(broadcast.CreatedAt)
+
+	data.ReplyTo = types.ListNull(types.StringType)
+	if len(broadcast.ReplyTo) > 0 {
+		replyTo, diags := types.ListValueFrom(ctx, types.StringType, broadcast.ReplyTo)
+		resp.Diagnostics.Append(diags...)
+		data.ReplyTo = replyTo
+	}
+
+	// The API returns the time the broadcast is scheduled for, which is kept
+	// as configured, e.g. in natural language. Drafts have not been sent,
+	// e.g. because sending failed, so the next apply sends them. Imported
+	// broadcasts count as sent unless they are drafts.
+	if broadcast.Status == broadcastStatusDraft {
+		data.Send = types.BoolValue(false)
+	} else if data.Send.IsNull() {
+		data.Send = types.BoolValue(true)
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BroadcastResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withClientLogMasking(ctx, r.client)
+
+	var data, state BroadcastResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// ModifyPlan only allows changes to drafts, other than send.
+	if state.Status.ValueString() == broadcastStatusDraft {
+		var replyTo []string
+		resp.Diagnostics.Append(data.ReplyTo.ElementsAs(ctx, &replyTo, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		// An empty list removes the reply-to addresses, null would keep them.
+		if replyTo == nil {
+			replyTo = []string{}
+		}
+
+		params := &updateBroadcastRequest{
+			SegmentId: data.AudienceId.ValueString(),
+			From:      data.From.ValueString(),
+			Subject:   data.Subject.ValueString(),
+			Html:      data.Html.ValueString(),
+			Text:      data.Text.ValueString(),
+			ReplyTo:   replyTo,
+			Name:      data.Name.ValueString(),
+		}
+
+		httpReq, err := r.client.NewRequest(ctx, http.MethodPatch, "broadcasts/"+data.Id.ValueString(), params)
+		if err == nil {
+			_, err = r.client.Perform(httpReq, nil)
+		}
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update broadcast, got error: %s", err))
+			return
+		}
+
+		if data.Send.ValueBool() {
+			r.send(ctx, &data, &resp.Diagnostics)
+		}
+	}
+	r.readStatus(ctx, &data, &resp.Diagnostics)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// send sends or schedules the broadcast. Errors are added to diags and reset
+// send to false.
+func (r *BroadcastResource) send(ctx context.Context, data *BroadcastResourceModel, diags *diag.Diagnostics) {
+	_, err := r.client.Broadcasts.SendWithContext(ctx, &resend.SendBroadcastRequest{
+		BroadcastId: data.Id.ValueString(),
+		ScheduledAt: data.ScheduledAt.ValueString(),
+	})
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to send broadcast, got error: %s", err))
+		// The broadcast is still a draft, which the next plan sends again.
+		data.Send = types.BoolValue(false)
+		return
+	}
+
+	tflog.Info(ctx, "Sent broadcast", map[string]interface{}{
+		"id":           data.Id.ValueString(),
+		"scheduled_at": data.ScheduledAt.ValueString(),
+	})
+}
+
+// readStatus sets the status and creation time of the broadcast, which are
+// not part of the responses of the create, update and send endpoints.
+func (r *BroadcastResource) readStatus(ctx context.Context, data *BroadcastResourceModel, diags *diag.Diagnostics) {
+	data.Status = types.StringNull()
+	if data.CreatedAt.IsUnknown() {
+		data.CreatedAt = types.StringNull()
+	}
+
+	broadcast, err := r.client.Broadcasts.GetWithContext(ctx, data.Id.ValueString())
+	if err != nil {
+		diags.AddWarning("Client Error", fmt.Sprintf("Unable to read broadcast to read its status, got error: %s", err))
+		return
+	}
+	data.Status = types.StringValue(broadcast.Status)
+	data.CreatedAt = types.StringValue(broadcast.CreatedAt)
+}
+
+func (r *BroadcastResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withClientLogMasking(ctx, r.client)
+
+	var data BroadcastResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	broadcast, err := r.client.Broadcasts.GetWithContext(ctx, data.Id.ValueString())
+	// The broadcast has already been deleted outside of Terraform.
+	if isNotFound(err) {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read broadcast, got error: %s", err))
+		return
+	}
+
+	// Sent broadcasts cannot be deleted, only removed from the state.
+	if broadcast.Status != broadcastStatusDraft && broadcast.Status != broadcastStatusScheduled {
+		resp.Diagnostics.AddWarning(
+			"Broadcast Not Deleted",
+			fmt.Sprintf("Broadcast %s has status %s and is kept in Resend, only drafts and scheduled broadcasts can be deleted. It has been removed from the Terraform state.",
+				data.Id.ValueString(), broadcast.Status),
+		)
+		return
+	}
+
+	// Deleting a scheduled broadcast cancels it.
+	_, err = r.client.Broadcasts.RemoveWithContext(ctx, data.Id.ValueString())
+	if isNotFound(err) {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete broadcast, got error: %s", err))
+		return
+	}
+}
+
+func (r *BroadcastResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx = withClientLogMasking(ctx, r.client)
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,218 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func testAccBroadcastConfig(attributes string) string {
+	return providerConfig + fmt.Sprintf(`
+resource "resend_audience" "test" {
+  name = "broadcasts"
+}
+
+resource "resend_broadcast" "test" {
+  audience_id = resend_audience.test.id
+  from        = "Acme <news@example.com>"
+%s
+}
+`, attributes)
+}
+
+// testAccCheckBroadcastStatus checks the status of the broadcast with the
+// given ID in Resend, or that it does not exist if status is empty.
+func testAccCheckBroadcastStatus(id *string, status string) func(*terraform.State) error {
+	return func(s *terraform.State) error {
+		broadcast, err := testAccClient().Broadcasts.Get(*id)
+		if status == "" {
+			if !isNotFound(err) {
+				return fmt.Errorf("expected broadcast %s to be deleted, got error: %v", *id, err)
+			}
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if broadcast.Status != status {
+			return fmt.Errorf("expected broadcast %s to have status %s, got %s", *id, status, broadcast.Status)
+		}
+		return nil
+	}
+}
+
+func TestAccBroadcastResource(t *testing.T) {
+	var id string
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		// Deleting a scheduled broadcast cancels it
+		CheckDestroy: testAccCheckBroadcastStatus(&id, ""),
+		Steps: []resource.TestStep{
+			// Placeholders are validated before the broadcast is created
+			{
+				Config: testAccBroadcastConfig(`
+  subject = "Release 1.0"
+  html    = "<p>Hi {{{FIRST_NAME|there}}, release 1.0 is out</p>"
+`),
+				ExpectError: regexp.MustCompile(`\{\{\{FIRST_NAME\|there\}\.\.\. is not closed with \}\}\}`),
+			},
+			// Create and Read testing
+			{
+				Config: testAccBroadcastConfig(`
+  subject  = "Release 1.0"
+  html     = "<p>Release 1.0 is out</p>"
+  reply_to = ["support@example.com"]
+  name     = "Release 1.0"
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("resend_broadcast.test", "audience_id", "resend_audience.test", "id"),
+					resource.TestCheckResourceAttr("resend_broadcast.test", "subject", "Release 1.0"),
+					resource.TestCheckResourceAttr("resend_broadcast.test", "reply_to.#", "1"),
+					resource.TestCheckResourceAttr("resend_broadcast.test", "send", "false"),
+					resource.TestCheckResourceAttr("resend_broadcast.test", "status", "draft"),
+					resource.TestCheckResourceAttrSet("resend_broadcast.test", "id"),
+					resource.TestCheckResourceAttrSet("resend_broadcast.test", "created_at"),
+					testAccCaptureAttr("resend_broadcast.test", "id", &id),
+				),
+			},
+			// Update testing, drafts are updated in place
+			{
+				Config: testAccBroadcastConfig(`
+  subject = "Release 1.1"
+  html    = "<p>Release 1.1 is out</p>"
+  text    = "Release 1.1 is out"
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPtr("resend_broadcast.test", "id", &id),
+					resource.TestCheckResourceAttr("resend_broadcast.test", "subject", "Release 1.1"),
+					resource.TestCheckResourceAttr("resend_broadcast.test", "text", "Release 1.1 is out"),
+					resource.TestCheckNoResourceAttr("resend_broadcast.test", "name"),
+					resource.TestCheckNoResourceAttr("resend_broadcast.test", "reply_to"),
+					resource.TestCheckResourceAttr("resend_broadcast.test", "status", "draft"),
+				),
+			},
+			// Scheduling testing
+			{
+				Config: testAccBroadcastConfig(`
+  subject      = "Release 1.1"
+  html         = "<p>Release 1.1 is out</p>"
+  text         = "Release 1.1 is out"
+  scheduled_at = "in 1 hour"
+  send         = true
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPtr("resend_broadcast.test", "id", &id),
+					resource.TestCheckResourceAttr("resend_broadcast.test", "status", "scheduled"),
+					testAccCheckBroadcastStatus(&id, "scheduled"),
+				),
+			},
+			// Scheduled broadcasts can no longer be changed
+			{
+				Config: testAccBroadcastConfig(`
+  subject      = "Release 1.2"
+  html         = "<p>Release 1.1 is out</p>"
+  text         = "Release 1.1 is out"
+  scheduled_at = "in 1 hour"
+  send         = true
+`),
+				ExpectError: regexp.MustCompile(`Broadcast Already Sent`),
+			},
+			// Scheduled broadcasts are not turned back into drafts
+			{
+				Config: testAccBroadcastConfig(`
+  subject      = "Release 1.1"
+  html         = "<p>Release 1.1 is out</p>"
+  text         = "Release 1.1 is out"
+  scheduled_at = "in 1 hour"
+  send         = false
+`),
+				ExpectError: regexp.MustCompile(`cannot\s+be\s+turned\s+back\s+into\s+a\s+draft`),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "resend_broadcast.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"scheduled_at"},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccBroadcastResourceSend(t *testing.T) {
+	var id string
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		// Sent broadcasts are kept
+		CheckDestroy: testAccCheckBroadcastStatus(&id, "sent"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBroadcastConfig(`
+  subject = "Release 2.0"
+  text    = "Release 2.0 is out"
+  send    = true
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("resend_broadcast.test", "status", "sent"),
+					testAccCaptureAttr("resend_broadcast.test", "id", &id),
+				),
+			},
+		},
+	})
+}
+
+func TestAccBroadcastResourceSendFailure(t *testing.T) {
+	if testAccFake == nil {
+		t.Skip("injecting errors requires the fake Resend API")
+	}
+
+	var id string
+	config := testAccBroadcastConfig(`
+  subject = "Release 3.0"
+  text    = "Release 3.0 is out"
+  send    = true
+`)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBroadcastConfig(`
+  subject = "Release 3.0"
+  text    = "Release 3.0 is out"
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCaptureAttr("resend_broadcast.test", "id", &id),
+				),
+			},
+			// Sending fails, the broadcast stays a draft
+			{
+				PreConfig: func() {
+					testAccFake.InjectError("POST", "/broadcasts/"+id+"/send", 422, 1)
+				},
+				Config:      config,
+				ExpectError: regexp.MustCompile(`Unable to send broadcast`),
+			},
+			// The next plan still sends the draft
+			{
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPtr("resend_broadcast.test", "id", &id),
+					resource.TestCheckResourceAttr("resend_broadcast.test", "send", "true"),
+					resource.TestCheckResourceAttr("resend_broadcast.test", "status", "sent"),
+				),
+			},
+		},
+	})
+}
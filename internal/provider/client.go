@@ -0,0 +1,327 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/resend/resend-go/v3"
+	"golang.org/x/time/rate"
+)
+
+const (
+	defaultBaseURL      = "https://api.resend.com/"
+	defaultTimeout      = time.Minute
+	defaultMaxRetries   = 3
+	defaultRetryMaxWait = 30 * time.Second
+
+	// defaultRequestsPerSecond is the default rate limit of a Resend account.
+	defaultRequestsPerSecond = 2
+
+	// retryBaseWait is the wait before the first retry, it doubles with
+	// every further retry.
+	retryBaseWait = 500 * time.Millisecond
+)
+
+// clientConfig holds the settings of the client used to talk to the Resend
+// API. Zero values fall back to the defaults, except for MaxRetries, which
+// disables retries, and RequestsPerSecond, which disables rate limiting.
+type clientConfig struct {
+	BaseURL            string
+	Timeout            time.Duration
+	InsecureSkipVerify bool
+	MaxRetries         int
+	RetryMaxWait       time.Duration
+	RequestsPerSecond  float64
+}
+
+// newClient creates the Resend API client that is shared by all resources
+// and data sources.
+func newClient(apiKey string, config clientConfig) (*resend.Client, error) {
+	baseURL := config.BaseURL
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+	// Paths are resolved relative to the base URL, without a trailing slash
+	// the last segment of its path would be dropped.
+	if !strings.HasSuffix(baseURL, "/") {
+		baseURL += "/"
+	}
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL: %w", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid base URL %q: must be an absolute http or https URL", config.BaseURL)
+	}
+
+	timeout := config.Timeout
+	if timeout == 0 {
+		timeout = defaultTimeout
+	}
+	retryMaxWait := config.RetryMaxWait
+	if retryMaxWait == 0 {
+		retryMaxWait = defaultRetryMaxWait
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if config.InsecureSkipVerify {
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+
+	var limited http.RoundTripper = &loggingTransport{
+		next: &timeoutTransport{
+			timeout: timeout,
+			next:    transport,
+		},
+	}
+	if config.RequestsPerSecond > 0 {
+		limited = &rateLimitTransport{
+			limiter: rate.NewLimiter(rate.Limit(config.RequestsPerSecond), 1),
+			next:    limited,
+		}
+	}
+
+	// The timeout applies to every attempt on its own, so it is not set on
+	// the http.Client, which would include the waits between retries and
+	// for the rate limiter.
+	httpClient := &http.Client{
+		Transport: &maskingTransport{
+			secrets: []string{apiKey},
+			next: &errorTransport{
+				secrets: []string{apiKey},
+				next: &retryTransport{
+					maxRetries: config.MaxRetries,
+					maxWait:    retryMaxWait,
+					next:       limited,
+				},
+			},
+		},
+	}
+
+	client := resend.NewCustomClient(httpClient, apiKey)
+	client.BaseURL = u
+
+	return client, nil
+}
+
+// apiError is returned for every response of the Resend API with a status
+// code outside of the 2xx range.
+type apiError struct {
+	StatusCode int    `json:"-"`
+	Name       string `json:"name"`
+	Message    string `json:"message"`
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("%s (HTTP %d)", e.Message, e.StatusCode)
+}
+
+// isNotFound reports whether err was caused by a 404 response.
+func isNotFound(err error) bool {
+	var apiErr *apiError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// errorTransport turns unsuccessful responses into an *apiError. The SDK only
+// keeps the message of an error response, which is not enough to tell a
+// missing object apart from any other failure. Secrets are masked in the
+// message, as it ends up in diagnostics.
+type errorTransport struct {
+	next    http.RoundTripper
+	secrets []string
+}
+
+func (t *errorTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || (resp.StatusCode >= 200 && resp.StatusCode < 300) {
+		return resp, err
+	}
+	defer resp.Body.Close()
+
+	apiErr := &apiError{StatusCode: resp.StatusCode}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err == nil {
+		// The body is not guaranteed to be JSON, e.g. for errors returned by
+		// a proxy. The status text is used as message in that case.
+		_ = json.Unmarshal(body, apiErr)
+	}
+	if apiErr.Message == "" {
+		apiErr.Message = http.StatusText(resp.StatusCode)
+	}
+	apiErr.Message = maskSecrets(apiErr.Message, t.secrets...)
+
+	return nil, apiErr
+}
+
+// retryTransport retries requests that failed because of rate limiting or a
+// server error, with exponential backoff and jitter.
+type retryTransport struct {
+	next       http.RoundTripper
+	maxRetries int
+	maxWait    time.Duration
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// The body has to be sent again with every attempt.
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		r := req.Clone(req.Context())
+		if body != nil {
+			r.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		resp, err := t.next.RoundTrip(r)
+		if err != nil || attempt >= t.maxRetries || !shouldRetry(req, resp) {
+			return resp, err
+		}
+
+		wait := t.backoff(attempt, resp)
+		tflog.Debug(req.Context(), "retrying Resend API request", map[string]interface{}{
+			"method":  req.Method,
+			"path":    req.URL.Path,
+			"status":  resp.StatusCode,
+			"attempt": attempt + 1,
+			"wait":    wait.String(),
+		})
+
+		// Drain the body so the connection can be reused.
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<20))
+		resp.Body.Close()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// shouldRetry reports whether a request that got resp can be sent again.
+// Rate limited requests have not been processed and are always retried,
+// server errors only for requests that are safe to repeat.
+func shouldRetry(req *http.Request, resp *http.Response) bool {
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	if resp.StatusCode < 500 {
+		return false
+	}
+
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return req.Header.Get("Idempotency-Key") != ""
+}
+
+// backoff returns how long to wait before the next attempt. The Retry-After
+// header of the response takes precedence over the exponential backoff,
+// both are capped at the maximum wait.
+func (t *retryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	if wait, ok := retryAfter(resp); ok {
+		return min(wait, t.maxWait)
+	}
+
+	wait := retryBaseWait << attempt
+	if wait <= 0 || wait > t.maxWait {
+		wait = t.maxWait
+	}
+
+	// Full jitter between half and the whole wait spreads out the retries of
+	// resources that are applied in parallel.
+	return wait/2 + time.Duration(rand.Int63n(int64(wait/2)+1))
+}
+
+// retryAfter parses the Retry-After header, which is either a number of
+// seconds or an HTTP date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+
+	return 0, false
+}
+
+// rateLimitTransport delays requests to stay below a number of requests per
+// second. Every retry counts as a request of its own.
+type rateLimitTransport struct {
+	next    http.RoundTripper
+	limiter *rate.Limiter
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+
+	return t.next.RoundTrip(req)
+}
+
+// timeoutTransport limits the time of a single request, including reading
+// the response body.
+type timeoutTransport struct {
+	next    http.RoundTripper
+	timeout time.Duration
+}
+
+func (t *timeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+
+	resp, err := t.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelReadCloser{ReadCloser: resp.Body, cancel: cancel}
+
+	return resp, nil
+}
+
+// cancelReadCloser cancels the context of a request once its response body
+// is closed.
+type cancelReadCloser struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelReadCloser) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/chronark/terraform-provider-resend/internal/resendfake"
+	"github.com/resend/resend-go/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(t *testing.T, config clientConfig) (*resendfake.Server, *resend.Client) {
+	t.Helper()
+
+	server := resendfake.NewServer()
+	t.Cleanup(server.Close)
+
+	config.BaseURL = server.URL
+	client, err := newClient("re_test", config)
+	require.NoError(t, err)
+
+	return server, client
+}
+
+func TestClientNotFound(t *testing.T) {
+	_, client := newTestClient(t, clientConfig{})
+
+	_, err := client.Domains.Get("missing")
+	require.True(t, isNotFound(err))
+	require.ErrorContains(t, err, "(HTTP 404)")
+}
+
+func TestClientRetriesRateLimitedRequests(t *testing.T) {
+	server, client := newTestClient(t, clientConfig{MaxRetries: 3, RetryMaxWait: time.Millisecond})
+
+	server.InjectError(http.MethodPost, "/domains", http.StatusTooManyRequests, 2)
+
+	_, err := client.Domains.Create(&resend.CreateDomainRequest{Name: "example.com"})
+	require.NoError(t, err)
+	require.Equal(t, 3, server.Requests(http.MethodPost, "/domains"))
+}
+
+func TestClientRetriesServerErrorsOfIdempotentRequests(t *testing.T) {
+	server, client := newTestClient(t, clientConfig{MaxRetries: 3, RetryMaxWait: time.Millisecond})
+
+	server.InjectError(http.MethodGet, "/api-keys", http.StatusBadGateway, 1)
+	_, err := client.ApiKeys.List()
+	require.NoError(t, err)
+	require.Equal(t, 2, server.Requests(http.MethodGet, "/api-keys"))
+
+	// Creating an object is not safe to repeat after a server error.
+	server.InjectError(http.MethodPost, "/api-keys", http.StatusInternalServerError, 1)
+	_, err = client.ApiKeys.Create(&resend.CreateApiKeyRequest{Name: "test"})
+	require.ErrorContains(t, err, "(HTTP 500)")
+	require.Equal(t, 1, server.Requests(http.MethodPost, "/api-keys"))
+}
+
+func TestClientGivesUpAfterMaxRetries(t *testing.T) {
+	server, client := newTestClient(t, clientConfig{MaxRetries: 2, RetryMaxWait: time.Millisecond})
+
+	server.InjectError(http.MethodGet, "/domains", http.StatusTooManyRequests, 5)
+
+	_, err := client.Domains.List()
+	require.ErrorContains(t, err, "(HTTP 429)")
+	require.Equal(t, 3, server.Requests(http.MethodGet, "/domains"))
+}
+
+func TestRetryBackoff(t *testing.T) {
+	transport := &retryTransport{maxWait: 10 * time.Second}
+
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"3"}}}
+	require.Equal(t, 3*time.Second, transport.backoff(0, resp))
+
+	resp.Header.Set("Retry-After", "120")
+	require.Equal(t, 10*time.Second, transport.backoff(0, resp))
+
+	resp.Header.Del("Retry-After")
+	for attempt := 0; attempt < 10; attempt++ {
+		wait := transport.backoff(attempt, resp)
+		expected := min(retryBaseWait<<attempt, transport.maxWait)
+		require.GreaterOrEqual(t, wait, expected/2)
+		require.LessOrEqual(t, wait, expected)
+	}
+}
+
+func TestClientRateLimit(t *testing.T) {
+	_, client := newTestClient(t, clientConfig{RequestsPerSecond: 20})
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		_, err := client.ApiKeys.List()
+		require.NoError(t, err)
+	}
+
+	// The first request is sent right away, every further one 50ms later.
+	require.GreaterOrEqual(t, time.Since(start), 190*time.Millisecond)
+}
+
+func TestClientMasksSecretsInErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"name":"validation_error","message":"API key re_test and re_123456789abc are invalid"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := newClient("re_test", clientConfig{BaseURL: server.URL})
+	require.NoError(t, err)
+
+	_, err = client.Domains.List()
+	require.ErrorContains(t, err, "API key *** and *** are invalid (HTTP 401)")
+}
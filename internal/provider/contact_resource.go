@@ -0,0 +1,363 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/resend/resend-go/v3"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ContactResource{}
+var _ resource.ResourceWithImportState = &ContactResource{}
+var _ resource.ResourceWithValidateConfig = &ContactResource{}
+var _ resource.ResourceWithModifyPlan = &ContactResource{}
+
+func NewContactResource() resource.Resource {
+	return &ContactResource{}
+}
+
+// ContactResource defines the resource implementation.
+type ContactResource struct {
+	client *resend.Client
+}
+
+// ContactResourceModel describes the resource data model.
+type ContactResourceModel struct {
+	Id               types.String `tfsdk:"id"`
+	AudienceId       types.String `tfsdk:"audience_id"`
+	Email            types.String `tfsdk:"email"`
+	FirstName        types.String `tfsdk:"first_name"`
+	LastName         types.String `tfsdk:"last_name"`
+	Unsubscribed     types.Bool   `tfsdk:"unsubscribed"`
+	KeepUnsubscribed types.Bool   `tfsdk:"keep_unsubscribed"`
+	CreatedAt        types.String `tfsdk:"created_at"`
+}
+
+// updateContactRequest is the body of a contact update. It is used instead of
+// resend.UpdateContactRequest, which drops empty names and so can never
+// remove the name of a contact. A nil Unsubscribed leaves the subscription
+// unchanged.
+type updateContactRequest struct {
+	FirstName    string `json:"first_name"`
+	LastName     string `json:"last_name"`
+	Unsubscribed *bool  `json:"unsubscribed,omitempty"`
+}
+
+func (r *ContactResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_contact"
+}
+
+func (r *ContactResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "A contact of an audience.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The unique identifier of the contact within Resend.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"audience_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the audience the contact belongs to.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"email": schema.StringAttribute{
+				MarkdownDescription: "The email address of the contact. Changing the email creates a new contact.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"first_name": schema.StringAttribute{
+				MarkdownDescription: "The first name of the contact.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"last_name": schema.StringAttribute{
+				MarkdownDescription: "The last name of the contact.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"unsubscribed": schema.BoolAttribute{
+				MarkdownDescription: "Whether the contact is unsubscribed from broadcasts. Recipients can unsubscribe themselves, see `keep_unsubscribed`. Defaults to `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"keep_unsubscribed": schema.BoolAttribute{
+				MarkdownDescription: "Keep the contact unsubscribed once the recipient has unsubscribed, instead of subscribing them again on the next apply. " +
+					"`unsubscribed` must not be set to `false` when enabled. Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "The date and time the contact was created.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ContactResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*resend.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *resend.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ContactResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	ctx = withClientLogMasking(ctx, r.client)
+
+	var data ContactResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Terraform does not let the provider change a value that is set in the
+	// configuration, so an explicit false would still resubscribe contacts.
+	if data.KeepUnsubscribed.ValueBool() && !data.Unsubscribed.IsNull() && !data.Unsubscribed.IsUnknown() && !data.Unsubscribed.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("unsubscribed"),
+			"Invalid Attribute Combination",
+			"unsubscribed cannot be false when keep_unsubscribed is enabled, as Terraform would subscribe contacts that have unsubscribed again. "+
+				"Remove unsubscribed to keep the subscription status of the contact.",
+		)
+	}
+}
+
+func (r *ContactResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	ctx = withClientLogMasking(ctx, r.client)
+
+	// Nothing to keep when the contact is created or destroyed.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state, config ContactResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.KeepUnsubscribed.ValueBool() || !state.Unsubscribed.ValueBool() || !config.Unsubscribed.IsNull() {
+		return
+	}
+
+	tflog.Info(ctx, "contact has unsubscribed, keeping it unsubscribed", map[string]interface{}{
+		"id": state.Id.ValueString(),
+	})
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("unsubscribed"), true)...)
+}
+
+func (r *ContactResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withClientLogMasking(ctx, r.client)
+
+	var data ContactResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	contact, err := r.client.Contacts.CreateWithContext(ctx, &resend.CreateContactRequest{
+		AudienceId:   data.AudienceId.ValueString(),
+		Email:        data.Email.ValueString(),
+		FirstName:    data.FirstName.ValueString(),
+		LastName:     data.LastName.ValueString(),
+		Unsubscribed: data.Unsubscribed.ValueBool(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create contact, got error: %s", err))
+		return
+	}
+	data.Id = types.StringValue(contact.Id)
+	data.CreatedAt = types.StringNull()
+
+	// The creation time is not part of the create response.
+	created, err := r.client.Contacts.GetWithContext(ctx, &resend.GetContactOptions{
+		AudienceId: data.AudienceId.ValueString(),
+		Id:         contact.Id,
+	})
+	if err != nil {
+		resp.Diagnostics.AddWarning("Client Error", fmt.Sprintf("Unable to read contact to read the creation time, got error: %s", err))
+	} else {
+		data.CreatedAt = types.StringValue(created.CreatedAt)
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ContactResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withClientLogMasking(ctx, r.client)
+
+	var data ContactResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	contact, err := r.client.Contacts.GetWithContext(ctx, &resend.GetContactOptions{
+		AudienceId: data.AudienceId.ValueString(),
+		Id:         data.Id.ValueString(),
+	})
+	if isNotFound(err) {
+		tflog.Warn(ctx, "contact not found, removing from state", map[string]interface{}{
+			"id": data.Id.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read contact, got error: %s", err))
+		return
+	}
+	data.Id = types.StringValue(contact.Id)
+	data.Email = types.StringValue(contact.Email)
+	data.FirstName = stringOrNull(contact.FirstName)
+	data.LastName = stringOrNull(contact.LastName)
+	data.Unsubscribed = types.BoolValue(contact.Unsubscribed)
+	data.CreatedAt = types.StringValue(contact.CreatedAt)
+
+	// Imported contacts use the default.
+	if data.KeepUnsubscribed.IsNull() {
+		data.KeepUnsubscribed = types.BoolValue(false)
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ContactResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withClientLogMasking(ctx, r.client)
+
+	var data ContactResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := updateContact(ctx, r.client, data.AudienceId.ValueString(), data.Id.ValueString(), &updateContactRequest{
+		FirstName:    data.FirstName.ValueString(),
+		LastName:     data.LastName.ValueString(),
+		Unsubscribed: data.Unsubscribed.ValueBoolPointer(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update contact, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ContactResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withClientLogMasking(ctx, r.client)
+
+	var data ContactResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.Contacts.RemoveWithContext(ctx, &resend.RemoveContactOptions{
+		AudienceId: data.AudienceId.ValueString(),
+		Id:         data.Id.ValueString(),
+	})
+	// The contact has already been deleted outside of Terraform, e.g. with
+	// its audience.
+	if isNotFound(err) {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete contact, got error: %s", err))
+		return
+	}
+}
+
+func (r *ContactResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx = withClientLogMasking(ctx, r.client)
+
+	audienceId, email, ok := strings.Cut(req.ID, "/")
+	if !ok || audienceId == "" || email == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: audience_id/email. Got: %q", req.ID),
+		)
+		return
+	}
+
+	// The contacts API accepts emails in place of IDs, Read replaces the
+	// email with the ID of the contact.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("audience_id"), audienceId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), email)...)
+}
+
+// stringOrNull returns a null string for empty values, which the API returns
+// for optional attributes that are not set.
+func stringOrNull(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+
+	return types.StringValue(value)
+}
@@ -0,0 +1,138 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/resend/resend-go/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func testAccContactConfig(attributes string) string {
+	return providerConfig + fmt.Sprintf(`
+resource "resend_audience" "test" {
+  name = "contacts"
+}
+
+resource "resend_contact" "test" {
+  audience_id = resend_audience.test.id
+  email       = "jane@example.com"
+%s
+}
+`, attributes)
+}
+
+// testAccUnsubscribeContact unsubscribes the contact in the state, like a
+// recipient following the unsubscribe link of a broadcast.
+func testAccUnsubscribeContact(t *testing.T, audienceId, id *string) func() {
+	return func() {
+		params := &resend.UpdateContactRequest{AudienceId: *audienceId, Id: *id}
+		params.SetUnsubscribed(true)
+		_, err := testAccClient().Contacts.Update(params)
+		require.NoError(t, err)
+	}
+}
+
+func TestAccContactResource(t *testing.T) {
+	var audienceId, id string
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// An explicit false would resubscribe contacts
+			{
+				Config: testAccContactConfig(`
+  unsubscribed      = false
+  keep_unsubscribed = true
+`),
+				ExpectError: regexp.MustCompile(`unsubscribed cannot be false when keep_unsubscribed is\s+enabled`),
+			},
+			// Create and Read testing
+			{
+				Config: testAccContactConfig(`
+  first_name = "Jane"
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("resend_contact.test", "audience_id", "resend_audience.test", "id"),
+					resource.TestCheckResourceAttr("resend_contact.test", "email", "jane@example.com"),
+					resource.TestCheckResourceAttr("resend_contact.test", "first_name", "Jane"),
+					resource.TestCheckNoResourceAttr("resend_contact.test", "last_name"),
+					resource.TestCheckResourceAttr("resend_contact.test", "unsubscribed", "false"),
+					resource.TestCheckResourceAttr("resend_contact.test", "keep_unsubscribed", "false"),
+					resource.TestCheckResourceAttrSet("resend_contact.test", "id"),
+					resource.TestCheckResourceAttrSet("resend_contact.test", "created_at"),
+					testAccCaptureAttr("resend_contact.test", "audience_id", &audienceId),
+					testAccCaptureAttr("resend_contact.test", "id", &id),
+				),
+			},
+			// Update testing, removing the first name clears it
+			{
+				Config: testAccContactConfig(`
+  last_name = "Doe"
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("resend_contact.test", "first_name"),
+					resource.TestCheckResourceAttr("resend_contact.test", "last_name", "Doe"),
+					resource.TestCheckResourceAttrPtr("resend_contact.test", "id", &id),
+					func(s *terraform.State) error {
+						contact, err := testAccClient().Contacts.Get(&resend.GetContactOptions{AudienceId: audienceId, Id: id})
+						if err != nil {
+							return err
+						}
+						if contact.FirstName != "" {
+							return fmt.Errorf("expected the first name to be removed, got %q", contact.FirstName)
+						}
+						return nil
+					},
+				),
+			},
+			// Contacts that unsubscribed are subscribed again by default
+			{
+				PreConfig: testAccUnsubscribeContact(t, &audienceId, &id),
+				Config: testAccContactConfig(`
+  last_name = "Doe"
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("resend_contact.test", "unsubscribed", "false"),
+				),
+			},
+			// keep_unsubscribed keeps them unsubscribed, without a diff on
+			// the next plan
+			{
+				PreConfig: testAccUnsubscribeContact(t, &audienceId, &id),
+				Config: testAccContactConfig(`
+  last_name         = "Doe"
+  keep_unsubscribed = true
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("resend_contact.test", "unsubscribed", "true"),
+					resource.TestCheckResourceAttr("resend_contact.test", "keep_unsubscribed", "true"),
+					func(s *terraform.State) error {
+						contact, err := testAccClient().Contacts.Get(&resend.GetContactOptions{AudienceId: audienceId, Id: id})
+						if err != nil {
+							return err
+						}
+						if !contact.Unsubscribed {
+							return fmt.Errorf("expected the contact to stay unsubscribed")
+						}
+						return nil
+					},
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName: "resend_contact.test",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return audienceId + "/jane@example.com", nil
+				},
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"keep_unsubscribed"},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
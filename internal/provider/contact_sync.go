@@ -0,0 +1,339 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/resend/resend-go/v3"
+)
+
+// contactSyncConcurrency is the number of contacts that are created, updated
+// or removed at the same time. The rate limit of the client applies on top.
+const contactSyncConcurrency = 10
+
+// syncContact is a contact as it should exist in an audience. A nil
+// Unsubscribed leaves the subscription to the recipient.
+type syncContact struct {
+	Email        string `json:"email"`
+	FirstName    string `json:"first_name"`
+	LastName     string `json:"last_name"`
+	Unsubscribed *bool  `json:"unsubscribed"`
+}
+
+// contactKey identifies a contact within an audience, emails are compared
+// case-insensitively.
+func contactKey(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// contactSyncPlan are the changes that bring the contacts of an audience in
+// line with the desired contacts.
+type contactSyncPlan struct {
+	Add    []syncContact
+	Update []syncUpdate
+	Remove []resend.Contact
+}
+
+// syncUpdate is a change to an existing contact.
+type syncUpdate struct {
+	Id      string
+	Contact syncContact
+}
+
+// empty reports whether the audience already has the desired contacts.
+func (p contactSyncPlan) empty() bool {
+	return len(p.Add) == 0 && len(p.Update) == 0 && len(p.Remove) == 0
+}
+
+// diffContacts compares the desired contacts with the contacts of an
+// audience. Contacts that are not desired are removed.
+func diffContacts(desired []syncContact, existing []resend.Contact) contactSyncPlan {
+	byKey := make(map[string]resend.Contact, len(existing))
+	for _, c := range existing {
+		byKey[contactKey(c.Email)] = c
+	}
+
+	var plan contactSyncPlan
+	for _, d := range desired {
+		key := contactKey(d.Email)
+		c, ok := byKey[key]
+		if !ok {
+			plan.Add = append(plan.Add, d)
+			continue
+		}
+		delete(byKey, key)
+
+		if c.FirstName != d.FirstName || c.LastName != d.LastName || (d.Unsubscribed != nil && c.Unsubscribed != *d.Unsubscribed) {
+			plan.Update = append(plan.Update, syncUpdate{Id: c.Id, Contact: d})
+		}
+	}
+
+	for _, c := range existing {
+		if _, ok := byKey[contactKey(c.Email)]; ok {
+			plan.Remove = append(plan.Remove, c)
+		}
+	}
+
+	return plan
+}
+
+// hashContacts returns a hash of the contacts that does not depend on their
+// order or the case of the emails.
+func hashContacts(contacts []syncContact) string {
+	lines := make([]string, len(contacts))
+	for i, c := range contacts {
+		unsubscribed := ""
+		if c.Unsubscribed != nil {
+			unsubscribed = strconv.FormatBool(*c.Unsubscribed)
+		}
+		lines[i] = strings.Join([]string{contactKey(c.Email), c.FirstName, c.LastName, unsubscribed}, "\x1f")
+	}
+	sort.Strings(lines)
+
+	sum := sha256.Sum256([]byte(strings.Join(lines, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+// hashExistingContacts returns the hash of the contacts of an audience, in
+// the form of hashContacts.
+func hashExistingContacts(existing []resend.Contact) string {
+	contacts := make([]syncContact, len(existing))
+	for i, c := range existing {
+		contacts[i] = syncContact{Email: c.Email, FirstName: c.FirstName, LastName: c.LastName, Unsubscribed: &c.Unsubscribed}
+	}
+
+	return hashContacts(contacts)
+}
+
+// checkContacts checks that every contact has an email and that no email is
+// used twice.
+func checkContacts(contacts []syncContact) error {
+	seen := make(map[string]bool, len(contacts))
+	for i, c := range contacts {
+		key := contactKey(c.Email)
+		if key == "" {
+			return fmt.Errorf("contact %d has no email", i+1)
+		}
+		if seen[key] {
+			return fmt.Errorf("the email %s is used by more than one contact", c.Email)
+		}
+		seen[key] = true
+	}
+
+	return nil
+}
+
+// readContactsFile reads the contacts of a CSV or JSON file, depending on its
+// extension.
+func readContactsFile(name string) ([]syncContact, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var contacts []syncContact
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".csv":
+		contacts, err = parseContactsCsv(f)
+	case ".json":
+		contacts, err = parseContactsJson(f)
+	default:
+		return nil, errors.New("the file must have a .csv or .json extension")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return contacts, checkContacts(contacts)
+}
+
+// parseContactsCsv parses contacts from CSV with a header row. The email
+// column is required, the first_name, last_name and unsubscribed columns are
+// optional and other columns are ignored, so exports of Resend can be used.
+func parseContactsCsv(r io.Reader) ([]syncContact, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.New("the file is empty, it needs at least a header row")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["email"]; !ok {
+		return nil, errors.New("the header row has no email column")
+	}
+
+	contacts := []syncContact{}
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			return contacts, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+
+		field := func(name string) string {
+			i, ok := columns[name]
+			if !ok || i >= len(row) {
+				return ""
+			}
+			return strings.TrimSpace(row[i])
+		}
+
+		contact := syncContact{
+			Email:     field("email"),
+			FirstName: field("first_name"),
+			LastName:  field("last_name"),
+		}
+		if value := field("unsubscribed"); value != "" {
+			unsubscribed, err := strconv.ParseBool(value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: unsubscribed must be true or false, got %q", line, value)
+			}
+			contact.Unsubscribed = &unsubscribed
+		}
+		contacts = append(contacts, contact)
+	}
+}
+
+// parseContactsJson parses contacts from a JSON array of objects with the
+// attributes of a contact.
+func parseContactsJson(r io.Reader) ([]syncContact, error) {
+	contacts := []syncContact{}
+	if err := json.NewDecoder(r).Decode(&contacts); err != nil {
+		return nil, fmt.Errorf("expected an array of contacts: %w", err)
+	}
+
+	for i := range contacts {
+		contacts[i].Email = strings.TrimSpace(contacts[i].Email)
+	}
+
+	return contacts, nil
+}
+
+// listContacts returns all contacts of an audience, following the pagination
+// of the list endpoint.
+func listContacts(ctx context.Context, client *resend.Client, audienceId string) ([]resend.Contact, error) {
+	var contacts []resend.Contact
+	limit := 100
+	options := &resend.ListContactsOptions{AudienceId: audienceId, Limit: &limit}
+	for {
+		page, err := client.Contacts.ListWithContext(ctx, options)
+		if err != nil {
+			return nil, err
+		}
+		contacts = append(contacts, page.Data...)
+
+		if !page.HasMore || len(page.Data) == 0 {
+			return contacts, nil
+		}
+		after := page.Data[len(page.Data)-1].Id
+		options.After = &after
+	}
+}
+
+// applyContactSync applies the changes of plan to an audience, with at most
+// contactSyncConcurrency requests at a time. Every change is attempted, the
+// errors of the failed ones are joined.
+func applyContactSync(ctx context.Context, client *resend.Client, audienceId string, plan contactSyncPlan) error {
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	slots := make(chan struct{}, contactSyncConcurrency)
+	run := func(f func() error) {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			if err := f(); err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, c := range plan.Add {
+		run(func() error {
+			_, err := client.Contacts.CreateWithContext(ctx, &resend.CreateContactRequest{
+				AudienceId:   audienceId,
+				Email:        c.Email,
+				FirstName:    c.FirstName,
+				LastName:     c.LastName,
+				Unsubscribed: c.Unsubscribed != nil && *c.Unsubscribed,
+			})
+			if err != nil {
+				return fmt.Errorf("add %s: %w", c.Email, err)
+			}
+			return nil
+		})
+	}
+	for _, u := range plan.Update {
+		run(func() error {
+			err := updateContact(ctx, client, audienceId, u.Id, &updateContactRequest{
+				FirstName:    u.Contact.FirstName,
+				LastName:     u.Contact.LastName,
+				Unsubscribed: u.Contact.Unsubscribed,
+			})
+			if err != nil {
+				return fmt.Errorf("update %s: %w", u.Contact.Email, err)
+			}
+			return nil
+		})
+	}
+	for _, c := range plan.Remove {
+		run(func() error {
+			_, err := client.Contacts.RemoveWithContext(ctx, &resend.RemoveContactOptions{AudienceId: audienceId, Id: c.Id})
+			// The contact has already been removed.
+			if err != nil && !isNotFound(err) {
+				return fmt.Errorf("remove %s: %w", c.Email, err)
+			}
+			return nil
+		})
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// updateContact sends a contact update with the given body.
+func updateContact(ctx context.Context, client *resend.Client, audienceId, id string, params *updateContactRequest) error {
+	req, err := client.NewRequest(ctx, http.MethodPatch, "audiences/"+audienceId+"/contacts/"+id, params)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.Perform(req, nil)
+	return err
+}
@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/resend/resend-go/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadContactsFile(t *testing.T) {
+	dir := t.TempDir()
+	unsubscribed := true
+
+	csvFile := filepath.Join(dir, "contacts.csv")
+	require.NoError(t, os.WriteFile(csvFile, []byte(`id,email,first_name,unsubscribed
+1,jane@example.com,Jane,true
+2, bob@example.com ,,
+`), 0o600))
+
+	contacts, err := readContactsFile(csvFile)
+	require.NoError(t, err)
+	require.Equal(t, []syncContact{
+		{Email: "jane@example.com", FirstName: "Jane", Unsubscribed: &unsubscribed},
+		{Email: "bob@example.com"},
+	}, contacts)
+
+	jsonFile := filepath.Join(dir, "contacts.json")
+	require.NoError(t, os.WriteFile(jsonFile, []byte(`[
+  {"email": "jane@example.com", "first_name": "Jane", "unsubscribed": true},
+  {"email": "bob@example.com", "created_at": "2024-01-01"}
+]`), 0o600))
+
+	contacts, err = readContactsFile(jsonFile)
+	require.NoError(t, err)
+	require.Equal(t, []syncContact{
+		{Email: "jane@example.com", FirstName: "Jane", Unsubscribed: &unsubscribed},
+		{Email: "bob@example.com"},
+	}, contacts)
+
+	require.NoError(t, os.WriteFile(csvFile, []byte("email,unsubscribed\njane@example.com,maybe\n"), 0o600))
+	_, err = readContactsFile(csvFile)
+	require.ErrorContains(t, err, `line 2: unsubscribed must be true or false, got "maybe"`)
+
+	require.NoError(t, os.WriteFile(csvFile, []byte("name\nJane\n"), 0o600))
+	_, err = readContactsFile(csvFile)
+	require.ErrorContains(t, err, "no email column")
+
+	require.NoError(t, os.WriteFile(csvFile, []byte("email\njane@example.com\nJANE@example.com\n"), 0o600))
+	_, err = readContactsFile(csvFile)
+	require.ErrorContains(t, err, "JANE@example.com is used by more than one contact")
+
+	_, err = readContactsFile(filepath.Join(dir, "contacts.txt"))
+	require.Error(t, err)
+}
+
+func TestDiffContacts(t *testing.T) {
+	unsubscribed := false
+	existing := []resend.Contact{
+		{Id: "1", Email: "Jane@example.com", FirstName: "Jane"},
+		{Id: "2", Email: "bob@example.com", Unsubscribed: true},
+		{Id: "3", Email: "carol@example.com", Unsubscribed: true},
+		{Id: "4", Email: "dave@example.com"},
+	}
+
+	plan := diffContacts([]syncContact{
+		// Unchanged, emails are compared case-insensitively
+		{Email: "jane@example.com", FirstName: "Jane"},
+		// The subscription is left to the recipient
+		{Email: "bob@example.com"},
+		// Subscribed again
+		{Email: "carol@example.com", Unsubscribed: &unsubscribed},
+		{Email: "erin@example.com"},
+	}, existing)
+
+	require.Equal(t, []syncContact{{Email: "erin@example.com"}}, plan.Add)
+	require.Equal(t, []syncUpdate{{Id: "3", Contact: syncContact{Email: "carol@example.com", Unsubscribed: &unsubscribed}}}, plan.Update)
+	require.Equal(t, []resend.Contact{existing[3]}, plan.Remove)
+	require.False(t, plan.empty())
+}
+
+func TestHashContacts(t *testing.T) {
+	a := syncContact{Email: "jane@example.com", FirstName: "Jane"}
+	b := syncContact{Email: "bob@example.com"}
+
+	require.Equal(t, hashContacts([]syncContact{a, b}), hashContacts([]syncContact{b, a}))
+	require.NotEqual(t, hashContacts([]syncContact{a, b}), hashContacts([]syncContact{a}))
+}
@@ -0,0 +1,343 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// apiKeyCommandTimeout limits how long api_key_command may run, e.g. when it
+// waits for an interactive login that never happens.
+const apiKeyCommandTimeout = time.Minute
+
+// apiKeyCommandStderrLimit limits how much of the error output of
+// api_key_command is shown in the diagnostic.
+const apiKeyCommandStderrLimit = 200
+
+// resolveApiKey returns the API key from the first source that is set, in the
+// order api_key, api_key_file, api_key_command, the profile and
+// RESEND_API_KEY. Only one of the attributes can be set, which is ensured by
+// the schema, and profile is nil if no profile is used. It returns an empty
+// string if no source is set or reading a source failed, in which case an
+// error is added to diags.
+func resolveApiKey(ctx context.Context, config ResendProviderModel, profile *credentialsProfile, diags *diag.Diagnostics) string {
+	switch {
+	case !config.ApiKey.IsNull():
+		return config.ApiKey.ValueString()
+
+	case !config.ApiKeyFile.IsNull():
+		apiKey, err := readApiKeyFile(config.ApiKeyFile.ValueString())
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("api_key_file"),
+				"Unable to read API key file",
+				fmt.Sprintf("The provider cannot read the Resend API key from %q, got error: %s", config.ApiKeyFile.ValueString(), err),
+			)
+		}
+		return apiKey
+
+	case !config.ApiKeyCommand.IsNull():
+		var args []string
+		diags.Append(config.ApiKeyCommand.ElementsAs(ctx, &args, false)...)
+		if diags.HasError() {
+			return ""
+		}
+
+		apiKey, err := runApiKeyCommand(ctx, args)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("api_key_command"),
+				"Unable to run API key command",
+				fmt.Sprintf("The provider cannot get the Resend API key from %q, got error: %s", strings.Join(args, " "), err),
+			)
+		}
+		return apiKey
+
+	case profile != nil:
+		return profile.ApiKey
+	}
+
+	return os.Getenv("RESEND_API_KEY")
+}
+
+// credentialsProfile is a named set of credentials in the credentials file.
+type credentialsProfile struct {
+	ApiKey  string
+	BaseURL string
+}
+
+// resolveProfile returns the profile selected with the profile attribute or
+// RESEND_PROFILE, or nil if no profile is selected. Errors are added to diags.
+func resolveProfile(config ResendProviderModel, diags *diag.Diagnostics) *credentialsProfile {
+	name := os.Getenv("RESEND_PROFILE")
+	if !config.Profile.IsNull() {
+		name = config.Profile.ValueString()
+	}
+	if name == "" {
+		return nil
+	}
+
+	filename, err := credentialsFilePath()
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("profile"),
+			"Unable to find credentials file",
+			fmt.Sprintf("The provider cannot find the credentials file of profile %q, got error: %s", name, err),
+		)
+		return nil
+	}
+
+	profiles, err := readCredentialsFile(filename)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("profile"),
+			"Unable to read credentials file",
+			fmt.Sprintf("The provider cannot read profile %q from the credentials file, got error: %s", name, err),
+		)
+		return nil
+	}
+
+	profile, ok := profiles[name]
+	if !ok {
+		names := make([]string, 0, len(profiles))
+		for name := range profiles {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		diags.AddAttributeError(
+			path.Root("profile"),
+			"Profile not found",
+			fmt.Sprintf("The credentials file %s has no profile %q. Available profiles: %s.", filename, name, strings.Join(names, ", ")),
+		)
+		return nil
+	}
+	if profile.ApiKey == "" {
+		diags.AddAttributeError(
+			path.Root("profile"),
+			"Missing api key",
+			fmt.Sprintf("Profile %q in the credentials file %s has no api_key.", name, filename),
+		)
+		return nil
+	}
+
+	return &profile
+}
+
+// credentialsFilePath returns the path of the credentials file, which is
+// $XDG_CONFIG_HOME/resend/credentials, by default ~/.config/resend/credentials.
+func credentialsFilePath() (string, error) {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "resend", "credentials"), nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, ".config", "resend", "credentials"), nil
+}
+
+// readCredentialsFile reads the profiles of a credentials file. The file has
+// a section per profile with api_key and base_url keys:
+//
+//	[production]
+//	api_key = "re_..."
+//
+//	[staging]
+//	api_key  = "re_..."
+//	base_url = "https://resend-proxy.example.com/"
+//
+// The file is parsed as TOML. Files that are not valid TOML are parsed as
+// INI, which only allows values without quotes, spaces or comments, or
+// values in quotes without escapes.
+func readCredentialsFile(filename string) (map[string]credentialsProfile, error) {
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var file map[string]struct {
+		ApiKey  string `toml:"api_key"`
+		BaseURL string `toml:"base_url"`
+	}
+	if _, err := toml.Decode(string(b), &file); err != nil {
+		return parseIniCredentials(filename, b)
+	}
+
+	profiles := map[string]credentialsProfile{}
+	for name, profile := range file {
+		profiles[name] = credentialsProfile{ApiKey: profile.ApiKey, BaseURL: profile.BaseURL}
+	}
+
+	return profiles, nil
+}
+
+// parseIniCredentials parses a credentials file in INI format. Lines that
+// could mean something else in TOML, e.g. values with inline comments or
+// escapes, are rejected instead of being read into a wrong API key.
+func parseIniCredentials(filename string, b []byte) (map[string]credentialsProfile, error) {
+	profiles := map[string]credentialsProfile{}
+	section := ""
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			name, err := iniValue(strings.TrimSpace(line[1 : len(line)-1]))
+			if err != nil {
+				return nil, fmt.Errorf("%s:%d: invalid profile name: %s", filename, n, err)
+			}
+			section = name
+			if _, ok := profiles[section]; !ok {
+				profiles[section] = credentialsProfile{}
+			}
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("%s:%d: expected a [profile] or key = value, got %q", filename, n, line)
+		}
+		key = strings.TrimSpace(key)
+		if section == "" {
+			return nil, fmt.Errorf("%s:%d: %s is not in a [profile]", filename, n, key)
+		}
+
+		value, err := iniValue(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid value of %s: %s", filename, n, key, err)
+		}
+
+		profile := profiles[section]
+		switch key {
+		case "api_key":
+			profile.ApiKey = value
+		case "base_url":
+			profile.BaseURL = value
+		}
+		profiles[section] = profile
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return profiles, nil
+}
+
+// iniValue returns an INI value without the double or single quotes around
+// it. Values that are only partly quoted, contain escapes or, without quotes,
+// contain spaces or comment characters are rejected. Errors do not contain
+// the value, as it may be an API key.
+func iniValue(value string) (string, error) {
+	if value != "" && (value[0] == '"' || value[0] == '\'') {
+		quote := value[0]
+		if len(value) < 2 || value[len(value)-1] != quote {
+			return "", fmt.Errorf("the value must end with %c and nothing after it, use TOML for comments after values", quote)
+		}
+		value = value[1 : len(value)-1]
+		if strings.IndexByte(value, quote) >= 0 || strings.Contains(value, "\\") {
+			return "", errors.New("quotes and escapes are only supported in TOML files")
+		}
+		return value, nil
+	}
+
+	if strings.ContainsAny(value, " \t\"'#;\\") {
+		return "", errors.New("values with spaces, quotes or comments must be quoted")
+	}
+
+	return value, nil
+}
+
+// readApiKeyFile reads an API key from a file, e.g. one rendered by Vault
+// Agent. Surrounding whitespace such as a trailing newline is removed.
+func readApiKeyFile(name string) (string, error) {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return "", err
+	}
+
+	apiKey := strings.TrimSpace(string(b))
+	if apiKey == "" {
+		return "", errors.New("the file is empty")
+	}
+
+	return apiKey, nil
+}
+
+// runApiKeyCommand runs a command, e.g. `op read`, and returns its output as
+// API key. The command is run directly, without a shell.
+func runApiKeyCommand(ctx context.Context, args []string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, apiKeyCommandTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if msg := stderrSnippet(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%w: %s", err, msg)
+		}
+		return "", err
+	}
+
+	apiKey := strings.TrimSpace(stdout.String())
+	if apiKey == "" {
+		return "", errors.New("the command did not print an API key")
+	}
+
+	return apiKey, nil
+}
+
+// stderrSnippet returns the first line of the error output of a command, with
+// secrets masked and cut off at apiKeyCommandStderrLimit characters. The
+// output ends up in a diagnostic and may contain the API key, e.g. when the
+// command prints its configuration on failure.
+func stderrSnippet(stderr string) string {
+	msg, _, _ := strings.Cut(strings.TrimSpace(stderr), "\n")
+	// Secrets are masked before cutting off the line, so that no part of a
+	// secret is left that the patterns do not match anymore.
+	msg = maskSecrets(strings.TrimSpace(msg))
+	if r := []rune(msg); len(r) > apiKeyCommandStderrLimit {
+		return string(r[:apiKeyCommandStderrLimit]) + "..."
+	}
+
+	return msg
+}
+
+// isListUnknown reports whether a list or any of its elements is unknown.
+func isListUnknown(value types.List) bool {
+	if value.IsUnknown() {
+		return true
+	}
+
+	for _, element := range value.Elements() {
+		if element.IsUnknown() {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,292 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveApiKey(t *testing.T) {
+	t.Setenv("RESEND_API_KEY", "re_env")
+
+	file := filepath.Join(t.TempDir(), "api_key")
+	require.NoError(t, os.WriteFile(file, []byte("re_file\n"), 0o600))
+	empty := filepath.Join(t.TempDir(), "empty")
+	require.NoError(t, os.WriteFile(empty, []byte("\n"), 0o600))
+
+	command := func(args ...string) types.List {
+		var values []attr.Value
+		for _, arg := range args {
+			values = append(values, types.StringValue(arg))
+		}
+		return types.ListValueMust(types.StringType, values)
+	}
+
+	tests := map[string]struct {
+		config ResendProviderModel
+		want   string
+		err    string
+	}{
+		"environment": {
+			config: ResendProviderModel{},
+			want:   "re_env",
+		},
+		"attribute": {
+			config: ResendProviderModel{ApiKey: types.StringValue("re_attr")},
+			want:   "re_attr",
+		},
+		"file": {
+			config: ResendProviderModel{ApiKeyFile: types.StringValue(file)},
+			want:   "re_file",
+		},
+		"missing file": {
+			config: ResendProviderModel{ApiKeyFile: types.StringValue(filepath.Join(t.TempDir(), "missing"))},
+			err:    "no such file or directory",
+		},
+		"empty file": {
+			config: ResendProviderModel{ApiKeyFile: types.StringValue(empty)},
+			err:    "the file is empty",
+		},
+		"command": {
+			config: ResendProviderModel{ApiKeyCommand: command("echo", "re_command")},
+			want:   "re_command",
+		},
+		"failing command": {
+			config: ResendProviderModel{ApiKeyCommand: command("sh", "-c", "echo not logged in >&2; exit 1")},
+			err:    "exit status 1: not logged in",
+		},
+		"failing command printing a secret": {
+			config: ResendProviderModel{ApiKeyCommand: command("sh", "-c", "echo invalid key re_123456789abc >&2; echo second line >&2; exit 1")},
+			err:    "exit status 1: invalid key ***",
+		},
+		"silent command": {
+			config: ResendProviderModel{ApiKeyCommand: command("true")},
+			err:    "the command did not print an API key",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			apiKey := resolveApiKey(context.Background(), test.config, nil, &diags)
+			if test.err != "" {
+				require.True(t, diags.HasError())
+				require.Contains(t, diags[0].Detail(), test.err)
+				return
+			}
+			require.False(t, diags.HasError(), diags)
+			require.Equal(t, test.want, apiKey)
+		})
+	}
+}
+
+func TestAccProviderApiKeySources(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "api_key")
+	require.NoError(t, os.WriteFile(file, []byte(os.Getenv("RESEND_API_KEY")+"\n"), 0o600))
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "resend" {
+  api_key      = "re_test"
+  api_key_file = %q
+}
+
+resource "resend_api_key" "test" {
+  name = "terraform"
+}
+`, file),
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+			{
+				Config: fmt.Sprintf(`
+provider "resend" {
+  api_key_file = %q
+}
+
+resource "resend_api_key" "test" {
+  name = "terraform"
+}
+`, file),
+				Check: resource.TestCheckResourceAttrSet("resend_api_key.test", "id"),
+			},
+			{
+				Config: `
+provider "resend" {
+  api_key_command = ["printenv", "RESEND_API_KEY"]
+}
+
+resource "resend_api_key" "test" {
+  name = "terraform"
+}
+`,
+				Check: resource.TestCheckResourceAttrSet("resend_api_key.test", "id"),
+			},
+		},
+	})
+}
+
+func TestReadCredentialsFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "credentials")
+	require.NoError(t, os.WriteFile(file, []byte(`
+# Resend accounts
+[production]
+api_key = re_production
+
+[staging]
+api_key  = "re_staging"
+base_url = 'https://resend-proxy.example.com/'
+`), 0o600))
+
+	profiles, err := readCredentialsFile(file)
+	require.NoError(t, err)
+	require.Equal(t, map[string]credentialsProfile{
+		"production": {ApiKey: "re_production"},
+		"staging":    {ApiKey: "re_staging", BaseURL: "https://resend-proxy.example.com/"},
+	}, profiles)
+
+	require.NoError(t, os.WriteFile(file, []byte("api_key = re_test\n"), 0o600))
+	_, err = readCredentialsFile(file)
+	require.ErrorContains(t, err, ":1: api_key is not in a [profile]")
+}
+
+func TestReadCredentialsFileToml(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "credentials")
+	require.NoError(t, os.WriteFile(file, []byte(`
+[production]
+api_key = "re_production" # rotated monthly
+
+[staging]
+api_key  = "re_\"quoted\"\u0041"
+base_url = 'https://resend-proxy.example.com/' # proxy
+`), 0o600))
+
+	profiles, err := readCredentialsFile(file)
+	require.NoError(t, err)
+	require.Equal(t, map[string]credentialsProfile{
+		"production": {ApiKey: "re_production"},
+		"staging":    {ApiKey: `re_"quoted"A`, BaseURL: "https://resend-proxy.example.com/"},
+	}, profiles)
+}
+
+func TestReadCredentialsFileRejectsAmbiguousIni(t *testing.T) {
+	tests := map[string]string{
+		"inline comment":          "[production]\napi_key = re_production # rotated\nbase_url = https://api.resend.com/\n",
+		"comment after quotes":    "[production]\napi_key = 're_production' ; rotated\nregion = us\n",
+		"escape in quotes":        "[production]\napi_key = \"re_\\\"production\"\nbase_url = https://api.resend.com/\n",
+		"quote in unquoted value": "[production]\napi_key = re_\"production\nbase_url = https://api.resend.com/\n",
+	}
+
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "credentials")
+			require.NoError(t, os.WriteFile(file, []byte(content), 0o600))
+
+			_, err := readCredentialsFile(file)
+			require.ErrorContains(t, err, ":2: invalid value of api_key")
+			require.NotContains(t, err.Error(), "re_")
+		})
+	}
+}
+
+func TestResolveProfile(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("RESEND_PROFILE", "")
+
+	var diags diag.Diagnostics
+	require.Nil(t, resolveProfile(ResendProviderModel{}, &diags))
+	require.False(t, diags.HasError())
+
+	profile := resolveProfile(ResendProviderModel{Profile: types.StringValue("production")}, &diags)
+	require.Nil(t, profile)
+	require.Equal(t, "Unable to read credentials file", diags[0].Summary())
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "resend"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "resend", "credentials"), []byte(`
+[production]
+api_key = re_production
+
+[empty]
+`), 0o600))
+
+	diags = nil
+	profile = resolveProfile(ResendProviderModel{Profile: types.StringValue("staging")}, &diags)
+	require.Nil(t, profile)
+	require.Equal(t, "Profile not found", diags[0].Summary())
+	require.Contains(t, diags[0].Detail(), "Available profiles: empty, production.")
+
+	diags = nil
+	profile = resolveProfile(ResendProviderModel{Profile: types.StringValue("empty")}, &diags)
+	require.Nil(t, profile)
+	require.Equal(t, "Missing api key", diags[0].Summary())
+
+	diags = nil
+	t.Setenv("RESEND_PROFILE", "production")
+	profile = resolveProfile(ResendProviderModel{}, &diags)
+	require.False(t, diags.HasError())
+	require.Equal(t, &credentialsProfile{ApiKey: "re_production"}, profile)
+}
+
+func TestAccProviderProfile(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+
+	credentials := fmt.Sprintf("[test]\napi_key = %q\n", os.Getenv("RESEND_API_KEY"))
+	if testAccFake != nil {
+		// The fake is only reachable if the base URL of the profile is used.
+		credentials += fmt.Sprintf("base_url = %q\n", testAccFake.URL)
+		t.Setenv("RESEND_BASE_URL", "")
+	}
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "resend"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "resend", "credentials"), []byte(credentials), 0o600))
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "resend" {
+  profile = "missing"
+}
+
+resource "resend_api_key" "test" {
+  name = "terraform"
+}
+`,
+				ExpectError: regexp.MustCompile(`Profile not found`),
+			},
+			{
+				Config: `
+provider "resend" {
+  profile = "test"
+}
+
+resource "resend_api_key" "test" {
+  name = "terraform"
+}
+`,
+				Check: resource.TestCheckResourceAttrSet("resend_api_key.test", "id"),
+			},
+		},
+	})
+}
+
+func TestStderrSnippet(t *testing.T) {
+	require.Equal(t, "not logged in", stderrSnippet("\n  not logged in\nrun op signin\n"))
+	require.Equal(t, "token *** expired", stderrSnippet("token re_123456789abc expired"))
+	require.Equal(t, strings.Repeat("x", apiKeyCommandStderrLimit)+"...", stderrSnippet(strings.Repeat("x", 1000)))
+	require.Empty(t, stderrSnippet(" \n"))
+}
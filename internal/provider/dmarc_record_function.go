@@ -0,0 +1,190 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &DmarcRecordFunction{}
+
+func NewDmarcRecordFunction() function.Function {
+	return &DmarcRecordFunction{}
+}
+
+// DmarcRecordFunction defines the function implementation.
+type DmarcRecordFunction struct{}
+
+// dmarcPolicies are the valid values of the p and sp tags.
+var dmarcPolicies = []string{"none", "quarantine", "reject"}
+
+// dmarcOptions are the optional tags of a DMARC record, in the order they are
+// written to the record.
+var dmarcOptions = []string{"sp", "ruf", "adkim", "aspf", "fo", "rf", "ri"}
+
+func (f *DmarcRecordFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "dmarc_record"
+}
+
+func (f *DmarcRecordFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Build the value of a DMARC record",
+		MarkdownDescription: "Builds the value of a [DMARC](https://datatracker.ietf.org/doc/html/rfc7489#section-6.3) TXT record, which is published as `_dmarc.<domain>`, " +
+			"e.g. `v=DMARC1; p=quarantine; pct=100; rua=mailto:dmarc@example.com`. All values are validated, so the record is not silently ignored by receivers.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "policy",
+				MarkdownDescription: "The policy for emails that fail DMARC. Possible values: `none` | `quarantine` | `reject`",
+			},
+			function.StringParameter{
+				Name:                "rua",
+				MarkdownDescription: "The addresses aggregate reports are sent to, separated by commas. Addresses without a scheme get `mailto:`. An empty string sends no reports.",
+			},
+			function.Int64Parameter{
+				Name:                "pct",
+				MarkdownDescription: "The percentage of failing emails the policy is applied to, from `0` to `100`.",
+			},
+		},
+		VariadicParameter: function.MapParameter{
+			Name:                "options",
+			MarkdownDescription: "Further tags of the record, optional. Possible keys: `sp` (policy for subdomains), `ruf` (addresses for failure reports), `adkim` and `aspf` (`r` for relaxed or `s` for strict alignment), `fo` (failure reporting options), `rf` (report format) and `ri` (report interval in seconds).",
+			ElementType:         types.StringType,
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *DmarcRecordFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	ctx = withLogMasking(ctx)
+
+	var policy, rua string
+	var pct int64
+	var optionsArgs []map[string]string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &policy, &rua, &pct, &optionsArgs))
+	if resp.Error != nil {
+		return
+	}
+
+	var options map[string]string
+	switch len(optionsArgs) {
+	case 0:
+	case 1:
+		options = optionsArgs[0]
+	default:
+		resp.Error = function.NewArgumentFuncError(4, fmt.Sprintf("options can only be passed once, got %d maps", len(optionsArgs)))
+		return
+	}
+
+	if !slices.Contains(dmarcPolicies, policy) {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("policy must be one of %s, got %q", strings.Join(dmarcPolicies, ", "), policy))
+		return
+	}
+	if pct < 0 || pct > 100 {
+		resp.Error = function.NewArgumentFuncError(2, fmt.Sprintf("pct must be between 0 and 100, got %d", pct))
+		return
+	}
+
+	tags := []string{"v=DMARC1", "p=" + policy}
+	if sp, ok := options["sp"]; ok {
+		if !slices.Contains(dmarcPolicies, sp) {
+			resp.Error = function.NewArgumentFuncError(3, fmt.Sprintf("sp must be one of %s, got %q", strings.Join(dmarcPolicies, ", "), sp))
+			return
+		}
+		tags = append(tags, "sp="+sp)
+	}
+	tags = append(tags, fmt.Sprintf("pct=%d", pct))
+	if rua = dmarcAddresses(rua); rua != "" {
+		tags = append(tags, "rua="+rua)
+	}
+
+	// The keys are sorted, so that the same option is reported first on
+	// every run.
+	keys := make([]string, 0, len(options))
+	for key := range options {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value := options[key]
+		if !slices.Contains(dmarcOptions, key) {
+			resp.Error = function.NewArgumentFuncError(3, fmt.Sprintf("unknown option %q, must be one of %s", key, strings.Join(dmarcOptions, ", ")))
+			return
+		}
+		if err := validateDmarcOption(key, value); err != nil {
+			resp.Error = function.NewArgumentFuncError(3, err.Error())
+			return
+		}
+	}
+	// sp is written right after the policy.
+	for _, key := range dmarcOptions[1:] {
+		value, ok := options[key]
+		if !ok {
+			continue
+		}
+		if key == "ruf" {
+			value = dmarcAddresses(value)
+		}
+		tags = append(tags, key+"="+value)
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, strings.Join(tags, "; ")))
+}
+
+// dmarcAddresses normalizes a comma-separated list of report addresses,
+// adding mailto: to addresses without a scheme.
+func dmarcAddresses(value string) string {
+	var addresses []string
+	for _, address := range strings.Split(value, ",") {
+		address = strings.TrimSpace(address)
+		if address == "" {
+			continue
+		}
+		if !strings.Contains(address, ":") {
+			address = "mailto:" + address
+		}
+		addresses = append(addresses, address)
+	}
+
+	return strings.Join(addresses, ",")
+}
+
+// validateDmarcOption checks the value of an optional tag of a DMARC record.
+func validateDmarcOption(key, value string) error {
+	switch key {
+	case "adkim", "aspf":
+		if value != "r" && value != "s" {
+			return fmt.Errorf("%s must be r or s, got %q", key, value)
+		}
+	case "fo":
+		for _, option := range strings.Split(value, ":") {
+			if !slices.Contains([]string{"0", "1", "d", "s"}, option) {
+				return fmt.Errorf("fo must be a colon-separated list of 0, 1, d and s, got %q", value)
+			}
+		}
+	case "rf":
+		if value != "afrf" {
+			return fmt.Errorf("rf must be afrf, got %q", value)
+		}
+	case "ri":
+		if n, err := strconv.ParseUint(value, 10, 32); err != nil || n == 0 {
+			return fmt.Errorf("ri must be a positive number of seconds, got %q", value)
+		}
+	case "ruf":
+		if dmarcAddresses(value) == "" {
+			return fmt.Errorf("ruf must not be empty")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccDmarcRecordFunction(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "minimal" {
+  value = provider::resend::dmarc_record("none", "", 100)
+}
+
+output "full" {
+  value = provider::resend::dmarc_record("quarantine", "dmarc@example.com, mailto:reports@example.org", 50, {
+    sp    = "reject"
+    adkim = "s"
+    aspf  = "r"
+    fo    = "1:d"
+    ruf   = "forensic@example.com"
+    ri    = "3600"
+  })
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("minimal", "v=DMARC1; p=none; pct=100"),
+					resource.TestCheckOutput("full", "v=DMARC1; p=quarantine; sp=reject; pct=50; rua=mailto:dmarc@example.com,mailto:reports@example.org; ruf=mailto:forensic@example.com; adkim=s; aspf=r; fo=1:d; ri=3600"),
+				),
+			},
+			{
+				Config: `
+output "test" {
+  value = provider::resend::dmarc_record("block", "", 100)
+}
+`,
+				ExpectError: regexp.MustCompile(`policy\s+must\s+be\s+one\s+of\s+none,\s+quarantine,\s+reject`),
+			},
+			{
+				Config: `
+output "test" {
+  value = provider::resend::dmarc_record("reject", "", 101)
+}
+`,
+				ExpectError: regexp.MustCompile(`pct\s+must\s+be\s+between\s+0\s+and\s+100`),
+			},
+			{
+				Config: `
+output "test" {
+  value = provider::resend::dmarc_record("reject", "", 100, { adkim = "strict" })
+}
+`,
+				ExpectError: regexp.MustCompile(`adkim\s+must\s+be\s+r\s+or\s+s`),
+			},
+			{
+				Config: `
+output "test" {
+  value = provider::resend::dmarc_record("reject", "", 100, { version = "DMARC2", policy = "none" })
+}
+`,
+				ExpectError: regexp.MustCompile(`unknown\s+option\s+"policy"`),
+			},
+			{
+				Config: `
+output "test" {
+  value = provider::resend::dmarc_record("reject", "", 100, { sp = "none" }, { ri = "3600" })
+}
+`,
+				ExpectError: regexp.MustCompile(`options\s+can\s+only\s+be\s+passed\s+once`),
+			},
+		},
+	})
+}
@@ -0,0 +1,180 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// defaultRecordTtl is the TTL of records whose TTL is `Auto`, for DNS formats
+// that require one.
+const defaultRecordTtl = 3600
+
+// maxTxtStringLength is the maximum length of a single character string in a
+// TXT record, longer values have to be split into several strings.
+const maxTxtStringLength = 255
+
+// DnsRecord is a DNS record passed to the record functions, e.g. an element of
+// the records attribute of resend_domain. The other attributes of the records
+// are ignored.
+type DnsRecord struct {
+	Name     types.String `tfsdk:"name"`
+	Type     types.String `tfsdk:"type"`
+	Value    types.String `tfsdk:"value"`
+	Ttl      types.String `tfsdk:"ttl"`
+	Priority types.Int64  `tfsdk:"priority"`
+}
+
+// dnsRecordAttrTypes are the attribute types of a DnsRecord.
+var dnsRecordAttrTypes = map[string]attr.Type{
+	"name":     types.StringType,
+	"type":     types.StringType,
+	"value":    types.StringType,
+	"ttl":      types.StringType,
+	"priority": types.Int64Type,
+}
+
+// recordsParameter is the records parameter of the record functions.
+func recordsParameter() function.ListParameter {
+	return function.ListParameter{
+		Name:                "records",
+		MarkdownDescription: "The DNS records, e.g. the `records` attribute of `resend_domain` or the `resend_domain` data source. Every record needs a `name`, `type`, `value`, `ttl` and `priority` attribute, `ttl` and `priority` can be null.",
+		ElementType:         types.ObjectType{AttrTypes: dnsRecordAttrTypes},
+	}
+}
+
+// zoneParameter is the parameter of the record functions with the DNS zone
+// the records are added to.
+func zoneParameter(name string) function.StringParameter {
+	return function.StringParameter{
+		Name:                name,
+		MarkdownDescription: "The DNS zone the records are added to, e.g. `example.com`. Relative record names, such as the ones returned by Resend, are relative to it.",
+	}
+}
+
+// normalizeZone removes the trailing dot of a zone name and checks that it is
+// not empty.
+func normalizeZone(zone string) (string, error) {
+	zone = strings.TrimSuffix(strings.TrimSpace(zone), ".")
+	if zone == "" {
+		return "", fmt.Errorf("the zone must not be empty")
+	}
+
+	return zone, nil
+}
+
+// recordFqdn returns the fully qualified name of a record, without the
+// trailing dot. Names ending with a dot are absolute, as are names that
+// already end with the zone. `@` and empty names are the zone itself, every
+// other name is relative to the zone.
+func recordFqdn(name, zone string) string {
+	switch {
+	case name == "" || name == "@":
+		return zone
+	case strings.HasSuffix(name, "."):
+		return strings.TrimSuffix(name, ".")
+	case strings.EqualFold(name, zone) || strings.HasSuffix(strings.ToLower(name), "."+strings.ToLower(zone)):
+		return name
+	}
+
+	return name + "." + zone
+}
+
+// recordRelativeName returns the name of a record as written in a zone file
+// with the given origin: `@` for the origin itself, a relative name for names
+// within the origin and an absolute name with a trailing dot for all others.
+func recordRelativeName(fqdn, origin string) string {
+	if strings.EqualFold(fqdn, origin) {
+		return "@"
+	}
+	if strings.HasSuffix(strings.ToLower(fqdn), "."+strings.ToLower(origin)) {
+		return fqdn[:len(fqdn)-len(origin)-1]
+	}
+
+	return fqdn + "."
+}
+
+// recordTtl returns the TTL of a record in seconds. It reports false if the
+// TTL is `Auto` or not set.
+func recordTtl(record DnsRecord) (int64, bool, error) {
+	ttl := record.Ttl.ValueString()
+	if ttl == "" || strings.EqualFold(ttl, "auto") {
+		return 0, false, nil
+	}
+
+	seconds, err := strconv.ParseInt(ttl, 10, 64)
+	if err != nil || seconds < 0 {
+		return 0, false, fmt.Errorf("invalid TTL %q of record %s, must be a number of seconds or Auto", ttl, record.Name.ValueString())
+	}
+
+	return seconds, true, nil
+}
+
+// recordTarget returns a host name, e.g. of an MX or CNAME record, as absolute
+// name with a trailing dot. Resend returns the targets without the dot.
+func recordTarget(value string) string {
+	if strings.HasSuffix(value, ".") {
+		return value
+	}
+
+	return value + "."
+}
+
+// txtValue returns the text of a TXT record. Resend returns some values, like
+// the SPF record, in quotes and others, like the DKIM key, without.
+func txtValue(value string) string {
+	if len(value) >= 2 && strings.HasPrefix(value, `"`) && strings.HasSuffix(value, `"`) {
+		return strings.ReplaceAll(value[1:len(value)-1], `\"`, `"`)
+	}
+
+	return value
+}
+
+// quoteTxt quotes the text of a TXT record for a zone file or Route 53. Text
+// longer than 255 characters is split into several quoted strings, which DNS
+// clients join again.
+func quoteTxt(value string) string {
+	text := txtValue(value)
+
+	var parts []string
+	for {
+		n := min(len(text), maxTxtStringLength)
+		part := strings.ReplaceAll(text[:n], `\`, `\\`)
+		part = strings.ReplaceAll(part, `"`, `\"`)
+		parts = append(parts, `"`+part+`"`)
+
+		text = text[n:]
+		if text == "" {
+			return strings.Join(parts, " ")
+		}
+	}
+}
+
+// recordData returns the data of a record as written in a zone file or Route
+// 53 change batch.
+func recordData(record DnsRecord) (string, error) {
+	value := record.Value.ValueString()
+
+	switch strings.ToUpper(record.Type.ValueString()) {
+	case "TXT":
+		return quoteTxt(value), nil
+	case "MX":
+		if record.Priority.IsNull() {
+			return "", fmt.Errorf("the MX record %s has no priority", record.Name.ValueString())
+		}
+		return fmt.Sprintf("%d %s", record.Priority.ValueInt64(), recordTarget(value)), nil
+	case "CNAME", "NS":
+		return recordTarget(value), nil
+	case "":
+		return "", fmt.Errorf("the record %s has no type", record.Name.ValueString())
+	}
+
+	return value, nil
+}
@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordFqdn(t *testing.T) {
+	tests := map[string]string{
+		"":                          "example.com",
+		"@":                         "example.com",
+		"send":                      "send.example.com",
+		"resend._domainkey.mail":    "resend._domainkey.mail.example.com",
+		"send.example.com":          "send.example.com",
+		"Send.Example.com":          "Send.Example.com",
+		"example.com":               "example.com",
+		"send.example.org.":         "send.example.org",
+		"send.notexample.com":       "send.notexample.com.example.com",
+		"resend._domainkey.example": "resend._domainkey.example.example.com",
+	}
+
+	for name, want := range tests {
+		require.Equal(t, want, recordFqdn(name, "example.com"), name)
+	}
+}
+
+func TestRecordRelativeName(t *testing.T) {
+	require.Equal(t, "@", recordRelativeName("example.com", "example.com"))
+	require.Equal(t, "send", recordRelativeName("send.example.com", "example.com"))
+	require.Equal(t, "send", recordRelativeName("send.Example.com", "example.com"))
+	require.Equal(t, "send.example.org.", recordRelativeName("send.example.org", "example.com"))
+}
+
+func TestQuoteTxt(t *testing.T) {
+	require.Equal(t, `"v=spf1 include:amazonses.com ~all"`, quoteTxt(`"v=spf1 include:amazonses.com ~all"`))
+	require.Equal(t, `"v=spf1 include:amazonses.com ~all"`, quoteTxt(`v=spf1 include:amazonses.com ~all`))
+	require.Equal(t, `"say \"hi\" \\o/"`, quoteTxt(`say "hi" \o/`))
+
+	long := strings.Repeat("a", 255) + strings.Repeat("b", 255) + "c"
+	require.Equal(t, `"`+strings.Repeat("a", 255)+`" "`+strings.Repeat("b", 255)+`" "c"`, quoteTxt(long))
+}
+
+func TestRecordTtl(t *testing.T) {
+	ttl, ok, err := recordTtl(DnsRecord{Ttl: types.StringValue("Auto")})
+	require.NoError(t, err)
+	require.False(t, ok)
+	require.Zero(t, ttl)
+
+	ttl, ok, err = recordTtl(DnsRecord{Ttl: types.StringNull()})
+	require.NoError(t, err)
+	require.False(t, ok)
+	require.Zero(t, ttl)
+
+	ttl, ok, err = recordTtl(DnsRecord{Ttl: types.StringValue("300")})
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.EqualValues(t, 300, ttl)
+
+	_, _, err = recordTtl(DnsRecord{Name: types.StringValue("send"), Ttl: types.StringValue("1h")})
+	require.ErrorContains(t, err, `invalid TTL "1h" of record send`)
+}
@@ -0,0 +1,238 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/resend/resend-go/v3"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &DomainDataSource{}
+
+func NewDomainDataSource() datasource.DataSource {
+	return &DomainDataSource{}
+}
+
+// DomainDataSource defines the data source implementation.
+type DomainDataSource struct {
+	client *resend.Client
+}
+
+// DomainDataSourceModel describes the data source data model.
+type DomainDataSourceModel struct {
+	Id        types.String `tfsdk:"id"`
+	Name      types.String `tfsdk:"name"`
+	Region    types.String `tfsdk:"region"`
+	CreatedAt types.String `tfsdk:"created_at"`
+	Status    types.String `tfsdk:"status"`
+	Records   types.List   `tfsdk:"records"`
+}
+
+func (d *DomainDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_domain"
+}
+
+func (d *DomainDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Look up an existing domain by its ID or name, e.g. one that is managed by another Terraform configuration.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The unique identifier of the domain within Resend. Exactly one of `id` and `name` must be set.",
+				Optional:            true,
+				Computed:            true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("id"), path.MatchRoot("name")),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the domain, e.g. `example.com`. Exactly one of `id` and `name` must be set.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "The region where emails are sent from.",
+				Computed:            true,
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "The date and time the domain was created.",
+				Computed:            true,
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "The status of the domain, e.g. `not_started`, `pending`, `verified` or `failed`.",
+				Computed:            true,
+			},
+			"records": recordsDataSourceAttribute(),
+		},
+	}
+}
+
+// recordsDataSourceAttribute is the schema of the DNS records of a domain in
+// a data source. It matches the records attribute of resend_domain.
+func recordsDataSourceAttribute() schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		MarkdownDescription: "The DNS records used to configure the domain.",
+		Computed:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"record": schema.StringAttribute{
+					MarkdownDescription: "The purpose of the record, e.g. `SPF` or `DKIM`.",
+					Computed:            true,
+				},
+				"name": schema.StringAttribute{
+					MarkdownDescription: "The name of the record.",
+					Computed:            true,
+				},
+				"type": schema.StringAttribute{
+					MarkdownDescription: "The type of the record.",
+					Computed:            true,
+				},
+				"ttl": schema.StringAttribute{
+					MarkdownDescription: "The TTL of the record.",
+					Computed:            true,
+				},
+				"status": schema.StringAttribute{
+					MarkdownDescription: "The status of the record.",
+					Computed:            true,
+				},
+				"value": schema.StringAttribute{
+					MarkdownDescription: "The value of the record.",
+					Computed:            true,
+				},
+				"priority": schema.Int64Attribute{
+					MarkdownDescription: "The priority of the record. Only set for `MX` records.",
+					Computed:            true,
+				},
+			},
+		},
+	}
+}
+
+func (d *DomainDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*resend.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *resend.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *DomainDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withClientLogMasking(ctx, d.client)
+
+	var data DomainDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := data.Id.ValueString()
+	if data.Id.IsNull() {
+		// Only the get endpoint returns the records, so the list is just used
+		// to find the ID of the domain.
+		domains, err := listDomains(ctx, d.client)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list domains, got error: %s", err))
+			return
+		}
+
+		domain, ok := findDomainByName(domains, data.Name.ValueString())
+		if !ok {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name"),
+				"Domain Not Found",
+				fmt.Sprintf("No domain named %q exists in the Resend account.", data.Name.ValueString()),
+			)
+			return
+		}
+		id = domain.Id
+	}
+
+	domain, err := d.client.Domains.GetWithContext(ctx, id)
+	if isNotFound(err) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("id"),
+			"Domain Not Found",
+			fmt.Sprintf("No domain with ID %q exists in the Resend account.", id),
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read domain, got error: %s", err))
+		return
+	}
+
+	data.Id = types.StringValue(domain.Id)
+	data.Name = types.StringValue(domain.Name)
+	data.Region = types.StringValue(domain.Region)
+	data.CreatedAt = types.StringValue(domain.CreatedAt)
+	data.Status = types.StringValue(domain.Status)
+
+	records, diags := recordsValue(ctx, domain.Records)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Records = records
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// listDomains returns all domains of the account, following the pagination of
+// the list endpoint. The domains do not include their records.
+func listDomains(ctx context.Context, client *resend.Client) ([]resend.Domain, error) {
+	var domains []resend.Domain
+	limit := 100
+	options := &resend.ListOptions{Limit: &limit}
+	for {
+		page, err := client.Domains.ListWithOptions(ctx, options)
+		if err != nil {
+			return nil, err
+		}
+		domains = append(domains, page.Data...)
+
+		if !page.HasMore || len(page.Data) == 0 {
+			return domains, nil
+		}
+		after := page.Data[len(page.Data)-1].Id
+		options.After = &after
+	}
+}
+
+// findDomainByName returns the domain with the given name. Domain names are
+// unique within an account and compared case-insensitively.
+func findDomainByName(domains []resend.Domain, name string) (resend.Domain, bool) {
+	for _, domain := range domains {
+		if strings.EqualFold(domain.Name, name) {
+			return domain, true
+		}
+	}
+
+	return resend.Domain{}, false
+}
@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccDomainDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Missing domains are an error
+			{
+				Config: providerConfig + `
+data "resend_domain" "test" {
+  name = "missing.resend.chronark.com"
+}
+`,
+				ExpectError: regexp.MustCompile(`Domain Not Found`),
+			},
+			{
+				Config: providerConfig + `
+data "resend_domain" "test" {
+  id   = "d91cd9bd-1176-453e-8fc1-35364d380206"
+  name = "data.resend.chronark.com"
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+			// Read testing
+			{
+				Config: providerConfig + `
+resource "resend_domain" "test" {
+  name   = "data.resend.chronark.com"
+  region = "eu-west-1"
+}
+
+data "resend_domain" "by_id" {
+  id = resend_domain.test.id
+}
+
+data "resend_domain" "by_name" {
+  name = "DATA.resend.chronark.com"
+
+  depends_on = [resend_domain.test]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.resend_domain.by_id", "name", "resend_domain.test", "name"),
+					resource.TestCheckResourceAttr("data.resend_domain.by_id", "region", "eu-west-1"),
+					resource.TestCheckResourceAttrSet("data.resend_domain.by_id", "created_at"),
+					resource.TestCheckResourceAttrSet("data.resend_domain.by_id", "status"),
+					resource.TestCheckResourceAttrPair("data.resend_domain.by_id", "records.#", "resend_domain.test", "records.#"),
+					resource.TestCheckResourceAttrPair("data.resend_domain.by_id", "records.0.value", "resend_domain.test", "records.0.value"),
+					resource.TestCheckResourceAttrPair("data.resend_domain.by_name", "id", "resend_domain.test", "id"),
+					resource.TestCheckResourceAttr("data.resend_domain.by_name", "name", "data.resend.chronark.com"),
+					resource.TestCheckResourceAttrPair("data.resend_domain.by_name", "records.#", "resend_domain.test", "records.#"),
+				),
+			},
+		},
+	})
+}
@@ -6,14 +6,20 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/http"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/resendlabs/resend-go"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/resend/resend-go/v3"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -29,6 +35,7 @@ type DomainResource struct {
 	client *resend.Client
 }
 
+// Record describes a single DNS record that has to be configured for a domain.
 type Record struct {
 	Record   types.String `tfsdk:"record"`
 	Name     types.String `tfsdk:"name"`
@@ -39,15 +46,71 @@ type Record struct {
 	Priority types.Int64  `tfsdk:"priority"`
 }
 
+// recordAttrTypes are the attribute types of a Record, used to build the
+// records list.
+var recordAttrTypes = map[string]attr.Type{
+	"record":   types.StringType,
+	"name":     types.StringType,
+	"type":     types.StringType,
+	"value":    types.StringType,
+	"ttl":      types.StringType,
+	"status":   types.StringType,
+	"priority": types.Int64Type,
+}
+
+// recordsValue converts the records returned by the Resend API into a list
+// value that can be stored in the Terraform state.
+func recordsValue(ctx context.Context, records []resend.Record) (types.List, diag.Diagnostics) {
+	data := make([]Record, len(records))
+	for i, r := range records {
+		data[i] = Record{
+			Record:   types.StringValue(r.Record),
+			Name:     types.StringValue(r.Name),
+			Type:     types.StringValue(r.Type),
+			Value:    types.StringValue(r.Value),
+			Ttl:      types.StringValue(r.Ttl),
+			Status:   types.StringValue(r.Status),
+			Priority: types.Int64Null(),
+		}
+		// Only MX records have a priority, for every other record it is empty.
+		if p, err := r.Priority.Int64(); err == nil {
+			data[i].Priority = types.Int64Value(p)
+		}
+	}
+
+	return types.ListValueFrom(ctx, types.ObjectType{AttrTypes: recordAttrTypes}, data)
+}
+
 // DomainResourceModel describes the resource data model.
 type DomainResourceModel struct {
-	Id          types.String `tfsdk:"id"`
-	Name        types.String `tfsdk:"name"`
-	Region      types.String `tfsdk:"region"`
-	CreatedAt   types.String `tfsdk:"created_at"`
-	Status      types.String `tfsdk:"status"`
-	DnsProvider types.String `tfsdk:"dns_provider"`
-	// Records     basetypes.ListValue `tfsdk:"records"`
+	Id            types.String `tfsdk:"id"`
+	Name          types.String `tfsdk:"name"`
+	Region        types.String `tfsdk:"region"`
+	CreatedAt     types.String `tfsdk:"created_at"`
+	Status        types.String `tfsdk:"status"`
+	DnsProvider   types.String `tfsdk:"dns_provider"`
+	Records       types.List   `tfsdk:"records"`
+	OpenTracking  types.Bool   `tfsdk:"open_tracking"`
+	ClickTracking types.Bool   `tfsdk:"click_tracking"`
+	Tls           types.String `tfsdk:"tls"`
+}
+
+// updateDomainRequest is the body of a domain update. It is used instead of
+// resend.UpdateDomainRequest, which drops tracking settings that are false
+// and so can never turn tracking off.
+type updateDomainRequest struct {
+	OpenTracking  *bool  `json:"open_tracking,omitempty"`
+	ClickTracking *bool  `json:"click_tracking,omitempty"`
+	Tls           string `json:"tls,omitempty"`
+}
+
+// domainResponse is a domain as returned by the API, including the tracking
+// and TLS settings, which resend.Domain does not have.
+type domainResponse struct {
+	resend.Domain
+	OpenTracking  *bool   `json:"open_tracking"`
+	ClickTracking *bool   `json:"click_tracking"`
+	Tls           *string `json:"tls"`
 }
 
 func (r *DomainResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -63,6 +126,9 @@ func (r *DomainResource) Schema(ctx context.Context, req resource.SchemaRequest,
 			"id": schema.StringAttribute{
 				MarkdownDescription: "The unique identifier of the domain within Resend.",
 				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"name": schema.StringAttribute{
 				MarkdownDescription: "The name of the domain you want to create",
@@ -81,6 +147,9 @@ func (r *DomainResource) Schema(ctx context.Context, req resource.SchemaRequest,
 			"created_at": schema.StringAttribute{
 				MarkdownDescription: "The date and time the domain was created",
 				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"status": schema.StringAttribute{
 				MarkdownDescription: "The status of the domain. TODO: find out possible values",
@@ -89,46 +158,64 @@ func (r *DomainResource) Schema(ctx context.Context, req resource.SchemaRequest,
 			"dns_provider": schema.StringAttribute{
 				MarkdownDescription: "The DNS provider used to configure the domain.",
 				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"open_tracking": schema.BoolAttribute{
+				MarkdownDescription: "Track the open rate of each email. Removing the attribute leaves the setting unchanged in Resend.",
+				Optional:            true,
+			},
+			"click_tracking": schema.BoolAttribute{
+				MarkdownDescription: "Track clicks within the body of each HTML email. Removing the attribute leaves the setting unchanged in Resend.",
+				Optional:            true,
+			},
+			"tls": schema.StringAttribute{
+				MarkdownDescription: `How TLS is used when delivering emails. Removing the attribute leaves the setting unchanged in Resend.
+- **opportunistic**: Try a secure connection first and fall back to an unencrypted one.
+- **enforced**: Only deliver emails over a secure connection.`,
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(resend.Opportunistic, resend.Enforced),
+				},
 			},
 
-			// "records": schema.ListNestedAttribute{
-			// 		MarkdownDescription: "The DNS records used to configure the domain.",
-			// Computed: true,
-			// Optional: true,
-			// 		NestedObject: schema.NestedAttributeObject{
-			// 			Attributes: map[string]schema.Attribute{
-			// 				"record": schema.StringAttribute{
-			// 					MarkdownDescription: "The Record Type.",
-			// 					Computed:            true,
-			// 				},
-			// 				"name": schema.StringAttribute{
-			// 					MarkdownDescription: "The name of the record.",
-			// 					Computed:            true,
-			// 				},
-			// 				"type": schema.StringAttribute{
-			// 					MarkdownDescription: "The type of the record.",
-			// 					Computed:            true,
-			// 				},
-			// 				"ttl": schema.StringAttribute{
-			// 					MarkdownDescription: "The TTL of the record.",
-			// 					Computed:            true,
-			// 				},
-			// 				"status": schema.StringAttribute{
-			// 					MarkdownDescription: "The status of the record.",
-			// 					Computed:            true,
-			// 				},
-			// 				"value": schema.StringAttribute{
-			// 					MarkdownDescription: "The value of the record.",
-			// 					Computed:            true,
-			// 				},
-			// 				"priority": schema.NumberAttribute{
-			// 					MarkdownDescription: "The priority of the record.",
-			// 					Computed:            true,
-			// 					Optional:            true,
-			// 				},
-			// 			},
-			// 		},
-			// },
+			"records": schema.ListNestedAttribute{
+				MarkdownDescription: "The DNS records used to configure the domain.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"record": schema.StringAttribute{
+							MarkdownDescription: "The purpose of the record, e.g. `SPF` or `DKIM`.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the record.",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "The type of the record.",
+							Computed:            true,
+						},
+						"ttl": schema.StringAttribute{
+							MarkdownDescription: "The TTL of the record.",
+							Computed:            true,
+						},
+						"status": schema.StringAttribute{
+							MarkdownDescription: "The status of the record.",
+							Computed:            true,
+						},
+						"value": schema.StringAttribute{
+							MarkdownDescription: "The value of the record.",
+							Computed:            true,
+						},
+						"priority": schema.Int64Attribute{
+							MarkdownDescription: "The priority of the record. Only set for `MX` records.",
+							Computed:            true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -154,6 +241,8 @@ func (r *DomainResource) Configure(ctx context.Context, req resource.ConfigureRe
 }
 
 func (r *DomainResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withClientLogMasking(ctx, r.client)
+
 	var data DomainResourceModel
 
 	// Read Terraform plan data into the model
@@ -163,7 +252,7 @@ func (r *DomainResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	domain, err := r.client.Domains.Create(&resend.CreateDomainRequest{
+	domain, err := r.client.Domains.CreateWithContext(ctx, &resend.CreateDomainRequest{
 		Name:   data.Name.ValueString(),
 		Region: data.Region.ValueString(),
 	})
@@ -172,34 +261,35 @@ func (r *DomainResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 	data.Id = types.StringValue(domain.Id)
+
 	data.CreatedAt = types.StringValue(domain.CreatedAt)
 	data.Status = types.StringValue(domain.Status)
 	data.DnsProvider = types.StringValue(domain.DnsProvider)
 	data.Region = types.StringValue(domain.Region)
-	data.Status = types.StringValue(domain.Status)
-	// data.Records = basetypes.ListValue{}
-
-	// for i, r := range domain.Records {
-	// 	data.Records[i] = record{
-	// 		Record: types.StringValue(r.Record),
-	// 		Name:   types.StringValue(r.Name),
-	// 		Type:   types.StringValue(r.Type),
-	// 		Ttl:    types.StringValue(r.Ttl),
-	// 		Status: types.StringValue(r.Status),
-	// 		Value:  types.StringValue(r.Value),
-	// 	}
-	// 	p, err := r.Priority.Int64()
-	// 	if err == nil {
-	// 		// I guess we can ignore this?
-	// 		data.Records[i].Priority = types.Int64Value(p)
-	// 	}
-	// }
+
+	records, diags := recordsValue(ctx, domain.Records)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Records = records
+
+	// The tracking and TLS settings can only be changed once the domain exists.
+	if !data.OpenTracking.IsNull() || !data.ClickTracking.IsNull() || !data.Tls.IsNull() {
+		err = r.updateSettings(ctx, data)
+		if err != nil {
+			// The domain is still saved, Terraform marks it as tainted.
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update domain settings, got error: %s", err))
+		}
+	}
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *DomainResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withClientLogMasking(ctx, r.client)
+
 	var data DomainResourceModel
 
 	// Read Terraform prior state data into the model
@@ -209,7 +299,14 @@ func (r *DomainResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
-	domain, err := r.client.Domains.Get(data.Id.ValueString())
+	domain, err := r.getDomain(ctx, data.Id.ValueString())
+	if isNotFound(err) {
+		tflog.Warn(ctx, "domain not found, removing from state", map[string]interface{}{
+			"id": data.Id.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read domain, got error: %s", err))
 		return
@@ -218,12 +315,22 @@ func (r *DomainResource) Read(ctx context.Context, req resource.ReadRequest, res
 	data.Region = types.StringValue(domain.Region)
 	data.CreatedAt = types.StringValue(domain.CreatedAt)
 	data.Status = types.StringValue(domain.Status)
+	setDomainSettings(&data, domain)
+
+	records, diags := recordsValue(ctx, domain.Records)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Records = records
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *DomainResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withClientLogMasking(ctx, r.client)
+
 	var data DomainResourceModel
 
 	// Read Terraform plan data into the model
@@ -233,13 +340,82 @@ func (r *DomainResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update domain, got error: %s", "not implemented"))
+	err := r.updateSettings(ctx, data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update domain, got error: %s", err))
+		return
+	}
+
+	domain, err := r.getDomain(ctx, data.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read domain, got error: %s", err))
+		return
+	}
+	data.Status = types.StringValue(domain.Status)
+	setDomainSettings(&data, domain)
+
+	records, diags := recordsValue(ctx, domain.Records)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Records = records
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// getDomain reads a domain with its tracking and TLS settings.
+func (r *DomainResource) getDomain(ctx context.Context, id string) (*domainResponse, error) {
+	req, err := r.client.NewRequest(ctx, http.MethodGet, "domains/"+id, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	domain := &domainResponse{}
+	if _, err := r.client.Perform(req, domain); err != nil {
+		return nil, err
+	}
+
+	return domain, nil
+}
+
+// setDomainSettings sets the tracking and TLS settings of the domain in the
+// model, so changes made outside of Terraform show up in the plan. Settings
+// that are not configured are left unmanaged and not read.
+func setDomainSettings(data *DomainResourceModel, domain *domainResponse) {
+	if !data.OpenTracking.IsNull() && domain.OpenTracking != nil {
+		data.OpenTracking = types.BoolValue(*domain.OpenTracking)
+	}
+	if !data.ClickTracking.IsNull() && domain.ClickTracking != nil {
+		data.ClickTracking = types.BoolValue(*domain.ClickTracking)
+	}
+	if !data.Tls.IsNull() && domain.Tls != nil {
+		data.Tls = types.StringValue(*domain.Tls)
+	}
+}
+
+// updateSettings applies the tracking and TLS settings of the model to the
+// domain. Settings that are not configured are left unchanged.
+func (r *DomainResource) updateSettings(ctx context.Context, data DomainResourceModel) error {
+	params := &updateDomainRequest{
+		OpenTracking:  data.OpenTracking.ValueBoolPointer(),
+		ClickTracking: data.ClickTracking.ValueBoolPointer(),
+		Tls:           data.Tls.ValueString(),
+	}
+
+	req, err := r.client.NewRequest(ctx, http.MethodPatch, "domains/"+data.Id.ValueString(), params)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.client.Perform(req, nil)
+	return err
+}
+
 func (r *DomainResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withClientLogMasking(ctx, r.client)
+
 	var data DomainResourceModel
 
 	// Read Terraform prior state data into the model
@@ -249,7 +425,11 @@ func (r *DomainResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	_, err := r.client.Domains.Remove(data.Id.ValueString())
+	_, err := r.client.Domains.RemoveWithContext(ctx, data.Id.ValueString())
+	// The domain has already been deleted outside of Terraform.
+	if isNotFound(err) {
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete domain, got error: %s", err))
 		return
@@ -258,5 +438,7 @@ func (r *DomainResource) Delete(ctx context.Context, req resource.DeleteRequest,
 }
 
 func (r *DomainResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx = withClientLogMasking(ctx, r.client)
+
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
 }
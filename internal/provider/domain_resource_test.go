@@ -1,12 +1,18 @@
 package provider
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/resend/resend-go/v3"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAccDomainResource(t *testing.T) {
+	var id string
+
 	resource.Test(t, resource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
@@ -22,14 +28,64 @@ resource "resend_domain" "test" {
 					resource.TestCheckResourceAttr("resend_domain.test", "name", "resend.chronark.com"),
 					resource.TestCheckResourceAttrSet("resend_domain.test", "id"),
 					resource.TestCheckResourceAttr("resend_domain.test", "region", "us-east-1"),
-					// resource.TestCheckResourceAttr("resend_domain.test", "records#", "2"),
-					// resource.TestCheckResourceAttrSet("resend_domain.test", "records.0.record"),
-					// resource.TestCheckResourceAttrSet("resend_domain.test", "records.0.name"),
-					// resource.TestCheckResourceAttrSet("resend_domain.test", "records.0.type"),
-					// resource.TestCheckResourceAttrSet("resend_domain.test", "records.0.ttl"),
-					// resource.TestCheckResourceAttrSet("resend_domain.test", "records.0.status"),
-					// resource.TestCheckResourceAttrSet("resend_domain.test", "records.0.value"),
-					// resource.TestCheckResourceAttrSet("resend_domain.test", "records.0.priority"),
+					resource.TestCheckResourceAttrSet("resend_domain.test", "records.#"),
+					resource.TestCheckResourceAttrSet("resend_domain.test", "records.0.record"),
+					resource.TestCheckResourceAttrSet("resend_domain.test", "records.0.name"),
+					resource.TestCheckResourceAttrSet("resend_domain.test", "records.0.type"),
+					resource.TestCheckResourceAttrSet("resend_domain.test", "records.0.ttl"),
+					resource.TestCheckResourceAttrSet("resend_domain.test", "records.0.status"),
+					resource.TestCheckResourceAttrSet("resend_domain.test", "records.0.value"),
+				),
+			},
+			// Update testing
+			{
+				Config: providerConfig + `
+resource "resend_domain" "test" {
+  name = "resend.chronark.com"
+  region = "us-east-1"
+  open_tracking = true
+  click_tracking = false
+  tls = "enforced"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("resend_domain.test", "open_tracking", "true"),
+					resource.TestCheckResourceAttr("resend_domain.test", "click_tracking", "false"),
+					resource.TestCheckResourceAttr("resend_domain.test", "tls", "enforced"),
+					testAccCaptureAttr("resend_domain.test", "id", &id),
+					func(*terraform.State) error {
+						if testAccFake == nil {
+							return nil
+						}
+						domain, ok := testAccFake.Domain(id)
+						if !ok {
+							return fmt.Errorf("domain %s not found", id)
+						}
+						if !domain.OpenTracking || domain.ClickTracking || domain.Tls != "enforced" {
+							return fmt.Errorf("unexpected domain settings: %+v", domain)
+						}
+						return nil
+					},
+				),
+			},
+			// Drift testing, the domain is created again after it has been
+			// deleted outside of Terraform
+			{
+				PreConfig: func() {
+					_, err := testAccClient().Domains.Remove(id)
+					require.NoError(t, err)
+				},
+				Config: providerConfig + `
+resource "resend_domain" "test" {
+  name = "resend.chronark.com"
+  region = "us-east-1"
+  open_tracking = true
+  click_tracking = false
+  tls = "enforced"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckAttrChanged("resend_domain.test", "id", &id),
 				),
 			},
 			// ImportState testing
@@ -41,3 +97,59 @@ resource "resend_domain" "test" {
 		},
 	})
 }
+
+func TestAccDomainResourceSettingsDrift(t *testing.T) {
+	var id string
+	config := providerConfig + `
+resource "resend_domain" "test" {
+  name = "resend.chronark.com"
+  region = "us-east-1"
+  click_tracking = false
+  tls = "opportunistic"
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCaptureAttr("resend_domain.test", "id", &id),
+				),
+			},
+			// Settings changed in the dashboard show up in the plan
+			{
+				PreConfig: func() {
+					_, err := testAccClient().Domains.Update(id, &resend.UpdateDomainRequest{
+						ClickTracking: true,
+						Tls:           resend.Enforced,
+					})
+					require.NoError(t, err)
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			// and are changed back by the next apply
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPtr("resend_domain.test", "id", &id),
+					resource.TestCheckResourceAttr("resend_domain.test", "click_tracking", "false"),
+					resource.TestCheckResourceAttr("resend_domain.test", "tls", "opportunistic"),
+					func(*terraform.State) error {
+						if testAccFake == nil {
+							return nil
+						}
+						domain, _ := testAccFake.Domain(id)
+						if domain.ClickTracking || domain.Tls != "opportunistic" {
+							return fmt.Errorf("unexpected domain settings: %+v", domain)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
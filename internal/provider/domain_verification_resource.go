@@ -0,0 +1,290 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/resend/resend-go/v3"
+)
+
+const (
+	domainStatusVerified = "verified"
+	domainStatusFailed   = "failed"
+)
+
+// domainVerificationPollInterval is the time between two status checks while
+// waiting for a domain to be verified.
+var domainVerificationPollInterval = 10 * time.Second
+
+// domainVerificationFirstPollDelay is the time between starting the
+// verification and the first status check. It is shorter than the poll
+// interval, so that short timeouts and domains that are verified right away
+// don't wait for a full interval.
+var domainVerificationFirstPollDelay = 2 * time.Second
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &DomainVerificationResource{}
+
+func NewDomainVerificationResource() resource.Resource {
+	return &DomainVerificationResource{}
+}
+
+// DomainVerificationResource defines the resource implementation.
+type DomainVerificationResource struct {
+	client *resend.Client
+}
+
+// DomainVerificationResourceModel describes the resource data model.
+type DomainVerificationResourceModel struct {
+	Id       types.String `tfsdk:"id"`
+	DomainId types.String `tfsdk:"domain_id"`
+	Timeout  types.String `tfsdk:"timeout"`
+	Status   types.String `tfsdk:"status"`
+}
+
+func (r *DomainVerificationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_domain_verification"
+}
+
+func (r *DomainVerificationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: `Trigger the verification of a domain and wait until it is verified.
+
+Create the DNS records of the domain first and let this resource depend on them. Destroying this resource does not change the domain. If the verification of the domain has failed when it is refreshed, the resource is removed from the state so the next apply verifies it again.`,
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The unique identifier of the verified domain.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"domain_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the domain to verify.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"timeout": schema.StringAttribute{
+				MarkdownDescription: "How long to wait for the verification to finish, as a duration such as `30s` or `10m`. Defaults to `10m`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("10m"),
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "The status of the domain.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *DomainVerificationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*resend.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *resend.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *DomainVerificationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withClientLogMasking(ctx, r.client)
+
+	var data DomainVerificationResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout, err := time.ParseDuration(data.Timeout.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("timeout"), "Invalid timeout", fmt.Sprintf("Unable to parse timeout, got error: %s", err))
+		return
+	}
+
+	_, err = r.client.Domains.VerifyWithContext(ctx, data.DomainId.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to verify domain, got error: %s", err))
+		return
+	}
+
+	domain, err := r.waitForVerification(ctx, data.DomainId.ValueString(), timeout)
+	if err != nil {
+		resp.Diagnostics.AddError("Domain Verification Error", err.Error())
+		return
+	}
+
+	data.Id = types.StringValue(domain.Id)
+	data.Status = types.StringValue(domain.Status)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// waitForVerification polls the domain until its status is either verified or
+// failed, or the timeout runs out.
+func (r *DomainVerificationResource) waitForVerification(ctx context.Context, domainId string, timeout time.Duration) (resend.Domain, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// The status of a domain is only updated after the verification has
+	// started, reading it right away could return the failed status of an
+	// earlier attempt.
+	select {
+	case <-ctx.Done():
+		return resend.Domain{}, fmt.Errorf("timed out after %s waiting for domain %s to be verified", timeout, domainId)
+	case <-time.After(domainVerificationFirstPollDelay):
+	}
+
+	ticker := time.NewTicker(domainVerificationPollInterval)
+	defer ticker.Stop()
+
+	for {
+		domain, err := r.client.Domains.GetWithContext(ctx, domainId)
+		if err != nil {
+			if ctx.Err() != nil {
+				return resend.Domain{}, fmt.Errorf("timed out after %s waiting for domain %s to be verified", timeout, domainId)
+			}
+			return resend.Domain{}, fmt.Errorf("unable to read domain, got error: %s", err)
+		}
+
+		tflog.Debug(ctx, "polled domain verification status", map[string]interface{}{
+			"domain_id": domainId,
+			"status":    domain.Status,
+		})
+
+		switch domain.Status {
+		case domainStatusVerified:
+			return domain, nil
+		case domainStatusFailed:
+			return resend.Domain{}, fmt.Errorf("verification of domain %s failed, check the following records: %s", domain.Name, failedRecords(domain.Records))
+		}
+
+		select {
+		case <-ctx.Done():
+			return resend.Domain{}, fmt.Errorf("timed out after %s waiting for domain %s to be verified, last status: %s", timeout, domain.Name, domain.Status)
+		case <-ticker.C:
+		}
+	}
+}
+
+// failedRecords lists the records that are not verified.
+func failedRecords(records []resend.Record) string {
+	var names []string
+	for _, record := range records {
+		if record.Status != domainStatusVerified {
+			names = append(names, fmt.Sprintf("%s %s (%s)", record.Type, record.Name, record.Status))
+		}
+	}
+	if len(names) == 0 {
+		return "none"
+	}
+
+	return strings.Join(names, ", ")
+}
+
+func (r *DomainVerificationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withClientLogMasking(ctx, r.client)
+
+	var data DomainVerificationResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domain, err := r.client.Domains.GetWithContext(ctx, data.DomainId.ValueString())
+	if isNotFound(err) {
+		tflog.Warn(ctx, "domain not found, removing verification from state", map[string]interface{}{
+			"domain_id": data.DomainId.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read domain, got error: %s", err))
+		return
+	}
+
+	// Other statuses, e.g. pending after the domain has been verified
+	// again, do not require a new verification.
+	if domain.Status == domainStatusFailed {
+		tflog.Warn(ctx, "domain verification failed, removing verification from state", map[string]interface{}{
+			"domain_id": domain.Id,
+			"status":    domain.Status,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	data.Status = types.StringValue(domain.Status)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DomainVerificationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withClientLogMasking(ctx, r.client)
+
+	var data DomainVerificationResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only the timeout can change without replacing the resource and it is
+	// not sent to Resend.
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DomainVerificationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withClientLogMasking(ctx, r.client)
+
+	// A verification cannot be undone, removing the resource from the state
+	// is all there is to do.
+	tflog.Trace(ctx, "removed domain verification from state")
+}
@@ -0,0 +1,157 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+const testAccDomainVerificationConfig = `
+resource "resend_domain" "test" {
+  name = "resend.chronark.com"
+  region = "us-east-1"
+}
+
+resource "resend_domain_verification" "test" {
+  domain_id = resend_domain.test.id
+}
+`
+
+func TestAccDomainVerificationResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The timeout is validated before the domain is verified
+			{
+				Config: providerConfig + `
+resource "resend_domain" "test" {
+  name = "resend.chronark.com"
+  region = "us-east-1"
+}
+
+resource "resend_domain_verification" "test" {
+  domain_id = resend_domain.test.id
+  timeout = "5 minutes"
+}
+`,
+				ExpectError: regexp.MustCompile(`The value must be a positive duration such as 30s, got: "5 minutes"`),
+			},
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "resend_domain" "test" {
+  name = "resend.chronark.com"
+  region = "us-east-1"
+}
+
+resource "resend_domain_verification" "test" {
+  domain_id = resend_domain.test.id
+  timeout = "5m"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("resend_domain_verification.test", "domain_id", "resend_domain.test", "id"),
+					resource.TestCheckResourceAttrPair("resend_domain_verification.test", "id", "resend_domain.test", "id"),
+					resource.TestCheckResourceAttr("resend_domain_verification.test", "status", "verified"),
+				),
+			},
+			// Update testing
+			{
+				Config: providerConfig + `
+resource "resend_domain" "test" {
+  name = "resend.chronark.com"
+  region = "us-east-1"
+}
+
+resource "resend_domain_verification" "test" {
+  domain_id = resend_domain.test.id
+  timeout = "10m"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("resend_domain_verification.test", "timeout", "10m"),
+					resource.TestCheckResourceAttr("resend_domain_verification.test", "status", "verified"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccDomainVerificationResourceStatus(t *testing.T) {
+	if testAccFake == nil {
+		t.Skip("changing the status of a domain requires the fake Resend API")
+	}
+
+	var id string
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + testAccDomainVerificationConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCaptureAttr("resend_domain_verification.test", "id", &id),
+				),
+			},
+			// A temporary failure does not require a new verification
+			{
+				PreConfig: func() {
+					testAccFake.SetDomainStatus(id, "temporary_failure")
+				},
+				Config:   providerConfig + testAccDomainVerificationConfig,
+				PlanOnly: true,
+			},
+			// A failed verification is removed from the state and started again
+			{
+				PreConfig: func() {
+					testAccFake.SetDomainStatus(id, "failed")
+				},
+				Config:             providerConfig + testAccDomainVerificationConfig,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: providerConfig + testAccDomainVerificationConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("resend_domain_verification.test", "status", "verified"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDomainVerificationResourceShortTimeout(t *testing.T) {
+	if testAccFake == nil {
+		t.Skip("the fake Resend API verifies domains right away")
+	}
+
+	// The status is checked once before the first poll interval has passed.
+	interval := domainVerificationPollInterval
+	domainVerificationPollInterval = time.Hour
+	t.Cleanup(func() { domainVerificationPollInterval = interval })
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "resend_domain" "test" {
+  name = "resend.chronark.com"
+  region = "us-east-1"
+}
+
+resource "resend_domain_verification" "test" {
+  domain_id = resend_domain.test.id
+  timeout = "5s"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("resend_domain_verification.test", "status", "verified"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,194 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/resend/resend-go/v3"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &DomainsDataSource{}
+
+func NewDomainsDataSource() datasource.DataSource {
+	return &DomainsDataSource{}
+}
+
+// DomainsDataSource defines the data source implementation.
+type DomainsDataSource struct {
+	client *resend.Client
+}
+
+// DomainsDataSourceModel describes the data source data model.
+type DomainsDataSourceModel struct {
+	Status    types.String `tfsdk:"status"`
+	Region    types.String `tfsdk:"region"`
+	NameRegex types.String `tfsdk:"name_regex"`
+	Domains   types.List   `tfsdk:"domains"`
+}
+
+// DomainSummary describes a domain in the list of resend_domains.
+type DomainSummary struct {
+	Id        types.String `tfsdk:"id"`
+	Name      types.String `tfsdk:"name"`
+	Region    types.String `tfsdk:"region"`
+	CreatedAt types.String `tfsdk:"created_at"`
+	Status    types.String `tfsdk:"status"`
+}
+
+// domainSummaryAttrTypes are the attribute types of a DomainSummary.
+var domainSummaryAttrTypes = map[string]attr.Type{
+	"id":         types.StringType,
+	"name":       types.StringType,
+	"region":     types.StringType,
+	"created_at": types.StringType,
+	"status":     types.StringType,
+}
+
+func (d *DomainsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_domains"
+}
+
+func (d *DomainsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "List the domains of the account, optionally filtered by status, region and name.",
+
+		Attributes: map[string]schema.Attribute{
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Only list domains with this status. Possible values: `not_started` | `pending` | `verified` | `failed` | `temporary_failure`",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("not_started", "pending", "verified", "failed", "temporary_failure"),
+				},
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "Only list domains that send emails from this region. Possible values: `us-east-1` | `eu-west-1` | `sa-east-1` | `ap-northeast-1`",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("us-east-1", "eu-west-1", "sa-east-1", "ap-northeast-1"),
+				},
+			},
+			"name_regex": schema.StringAttribute{
+				MarkdownDescription: "Only list domains whose name matches this [regular expression](https://github.com/google/re2/wiki/Syntax), e.g. `\\\\.example\\\\.com$`.",
+				Optional:            true,
+				Validators: []validator.String{
+					regexValidator{},
+				},
+			},
+			"domains": schema.ListNestedAttribute{
+				MarkdownDescription: "The matching domains, newest first. Use the `resend_domain` data source to get the DNS records of a domain.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The unique identifier of the domain within Resend.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the domain.",
+							Computed:            true,
+						},
+						"region": schema.StringAttribute{
+							MarkdownDescription: "The region where emails are sent from.",
+							Computed:            true,
+						},
+						"created_at": schema.StringAttribute{
+							MarkdownDescription: "The date and time the domain was created.",
+							Computed:            true,
+						},
+						"status": schema.StringAttribute{
+							MarkdownDescription: "The status of the domain.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *DomainsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*resend.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *resend.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *DomainsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx = withClientLogMasking(ctx, d.client)
+
+	var data DomainsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// name_regex has been checked by regexValidator.
+	var nameRegex *regexp.Regexp
+	if !data.NameRegex.IsNull() {
+		nameRegex = regexp.MustCompile(data.NameRegex.ValueString())
+	}
+
+	domains, err := listDomains(ctx, d.client)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list domains, got error: %s", err))
+		return
+	}
+
+	summaries := []DomainSummary{}
+	for _, domain := range domains {
+		if !data.Status.IsNull() && domain.Status != data.Status.ValueString() {
+			continue
+		}
+		if !data.Region.IsNull() && domain.Region != data.Region.ValueString() {
+			continue
+		}
+		if nameRegex != nil && !nameRegex.MatchString(domain.Name) {
+			continue
+		}
+
+		summaries = append(summaries, DomainSummary{
+			Id:        types.StringValue(domain.Id),
+			Name:      types.StringValue(domain.Name),
+			Region:    types.StringValue(domain.Region),
+			CreatedAt: types.StringValue(domain.CreatedAt),
+			Status:    types.StringValue(domain.Status),
+		})
+	}
+
+	list, diags := types.ListValueFrom(ctx, types.ObjectType{AttrTypes: domainSummaryAttrTypes}, summaries)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Domains = list
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/resend/resend-go/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccDomainsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+data "resend_domains" "test" {
+  name_regex = "["
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Regular Expression`),
+			},
+			// Read testing
+			{
+				Config: providerConfig + `
+resource "resend_domain" "us" {
+  name   = "us.list.resend.chronark.com"
+  region = "us-east-1"
+}
+
+resource "resend_domain" "eu" {
+  name   = "eu.list.resend.chronark.com"
+  region = "eu-west-1"
+}
+
+resource "resend_domain_verification" "eu" {
+  domain_id = resend_domain.eu.id
+}
+
+data "resend_domains" "all" {
+  name_regex = "\\.list\\.resend\\.chronark\\.com$"
+
+  depends_on = [resend_domain.us, resend_domain_verification.eu]
+}
+
+data "resend_domains" "eu" {
+  name_regex = "\\.list\\.resend\\.chronark\\.com$"
+  region     = "eu-west-1"
+
+  depends_on = [resend_domain.us, resend_domain_verification.eu]
+}
+
+data "resend_domains" "verified" {
+  name_regex = "\\.list\\.resend\\.chronark\\.com$"
+  status     = "verified"
+
+  depends_on = [resend_domain.us, resend_domain_verification.eu]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.resend_domains.all", "domains.#", "2"),
+					// Both domains are created at the same time, so their order is
+					// not known.
+					resource.TestCheckTypeSetElemAttrPair("data.resend_domains.all", "domains.*.id", "resend_domain.us", "id"),
+					resource.TestCheckTypeSetElemAttrPair("data.resend_domains.all", "domains.*.id", "resend_domain.eu", "id"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.resend_domains.all", "domains.*", map[string]string{
+						"name":   "us.list.resend.chronark.com",
+						"region": "us-east-1",
+					}),
+					resource.TestCheckResourceAttrSet("data.resend_domains.all", "domains.0.created_at"),
+					resource.TestCheckResourceAttrSet("data.resend_domains.all", "domains.0.status"),
+					resource.TestCheckResourceAttr("data.resend_domains.eu", "domains.#", "1"),
+					resource.TestCheckResourceAttrPair("data.resend_domains.eu", "domains.0.id", "resend_domain.eu", "id"),
+					resource.TestCheckResourceAttr("data.resend_domains.verified", "domains.#", "1"),
+					resource.TestCheckResourceAttrPair("data.resend_domains.verified", "domains.0.id", "resend_domain.eu", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestListDomainsPagination(t *testing.T) {
+	_, client := newTestClient(t, clientConfig{})
+
+	for i := 0; i < 150; i++ {
+		_, err := client.Domains.Create(&resend.CreateDomainRequest{Name: fmt.Sprintf("%d.example.com", i)})
+		require.NoError(t, err)
+	}
+
+	domains, err := listDomains(context.Background(), client)
+	require.NoError(t, err)
+	require.Len(t, domains, 150)
+	require.Equal(t, "149.example.com", domains[0].Name)
+	require.Equal(t, "0.example.com", domains[149].Name)
+}
@@ -0,0 +1,33 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDurationValidator(t *testing.T) {
+	tests := map[string]bool{
+		"30s":       true,
+		"1h30m":     true,
+		"5 minutes": false,
+		"10":        false,
+		"0s":        false,
+		"-1m":       false,
+	}
+
+	for value, valid := range tests {
+		req := validator.StringRequest{Path: path.Root("timeout"), ConfigValue: types.StringValue(value)}
+		resp := &validator.StringResponse{}
+		durationValidator{}.ValidateString(context.Background(), req, resp)
+		require.Equal(t, !valid, resp.Diagnostics.HasError(), value)
+	}
+
+	resp := &validator.StringResponse{}
+	durationValidator{}.ValidateString(context.Background(), validator.StringRequest{ConfigValue: types.StringNull()}, resp)
+	require.False(t, resp.Diagnostics.HasError())
+}
@@ -0,0 +1,218 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/resend/resend-go/v3"
+)
+
+// logSubsystem is the subsystem used to log the requests to the Resend API.
+// Its level can be set with TF_LOG_PROVIDER_RESEND_API.
+const logSubsystem = "resend_api"
+
+// redacted replaces secrets in logs.
+const redacted = "***"
+
+// sensitiveLogKeys are log fields and JSON keys whose values are secrets.
+var sensitiveLogKeys = []string{
+	"api_key",
+	"authorization",
+	"password",
+	"secret",
+	"signing_secret",
+	"token",
+}
+
+// secretPatterns match secrets wherever they show up in a log message or
+// field: Resend API keys, webhook signing secrets and bearer tokens. The
+// prefixes have to start a word, so that e.g. pre_processing_queue is kept.
+var secretPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`\bre_[A-Za-z0-9_]{8,}`),
+	regexp.MustCompile(`\bwhsec_[A-Za-z0-9+/=]+`),
+	regexp.MustCompile(`(?i)bearer\s+\S+`),
+}
+
+// withLogMasking registers the masking of secrets on the root logger of the
+// provider and on every subsystem logger. It has to be applied to every
+// context that is used for logging, masking does not carry over between
+// requests from Terraform.
+//
+// It is applied when the provider is configured, at the start of every method
+// of the resources, data sources and functions by withClientLogMasking and to
+// every request to the Resend API by maskingTransport.
+func withLogMasking(ctx context.Context, secrets ...string) context.Context {
+	ctx = tflog.NewSubsystem(ctx, logSubsystem, tflog.WithLevelFromEnv("TF_LOG_PROVIDER_RESEND_API"))
+
+	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, sensitiveLogKeys...)
+	ctx = tflog.MaskAllFieldValuesRegexes(ctx, secretPatterns...)
+	ctx = tflog.MaskMessageRegexes(ctx, secretPatterns...)
+
+	ctx = tflog.SubsystemMaskFieldValuesWithFieldKeys(ctx, logSubsystem, sensitiveLogKeys...)
+	ctx = tflog.SubsystemMaskAllFieldValuesRegexes(ctx, logSubsystem, secretPatterns...)
+	ctx = tflog.SubsystemMaskMessageRegexes(ctx, logSubsystem, secretPatterns...)
+
+	// Secrets that are known, but don't match any of the patterns.
+	for _, secret := range secrets {
+		if secret == "" {
+			continue
+		}
+		ctx = tflog.MaskAllFieldValuesStrings(ctx, secret)
+		ctx = tflog.MaskMessageStrings(ctx, secret)
+		ctx = tflog.SubsystemMaskAllFieldValuesStrings(ctx, logSubsystem, secret)
+		ctx = tflog.SubsystemMaskMessageStrings(ctx, logSubsystem, secret)
+	}
+
+	return ctx
+}
+
+// withClientLogMasking registers the masking of secrets on the context of a
+// resource, data source or function call, with the API key of client as a
+// known secret. Terraform creates a new context for every call, so it is
+// called at the start of every method that logs or sends requests. client is
+// nil before the provider is configured and for functions.
+func withClientLogMasking(ctx context.Context, client *resend.Client) context.Context {
+	if client == nil {
+		return withLogMasking(ctx)
+	}
+
+	return withLogMasking(ctx, client.ApiKey)
+}
+
+// maskSecrets replaces secrets in text that is shown outside of the logs,
+// e.g. in diagnostics, with the same patterns and known secrets as the log
+// masking.
+func maskSecrets(text string, secrets ...string) string {
+	for _, pattern := range secretPatterns {
+		text = pattern.ReplaceAllString(text, redacted)
+	}
+	for _, secret := range secrets {
+		if secret != "" {
+			text = strings.ReplaceAll(text, secret, redacted)
+		}
+	}
+
+	return text
+}
+
+// maskingTransport registers the masking of secrets on the context of every
+// request, so everything logged while the request is sent is masked, e.g. by
+// retryTransport and loggingTransport.
+type maskingTransport struct {
+	next    http.RoundTripper
+	secrets []string
+}
+
+func (t *maskingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.next.RoundTrip(req.WithContext(withLogMasking(req.Context(), t.secrets...)))
+}
+
+// loggingTransport logs every attempt of a request to the Resend API and its
+// response. Bodies are only logged at trace level and with secrets redacted.
+type loggingTransport struct {
+	next http.RoundTripper
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := tflog.SubsystemSetField(req.Context(), logSubsystem, "method", req.Method)
+	ctx = tflog.SubsystemSetField(ctx, logSubsystem, "path", req.URL.RequestURI())
+
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	if len(body) > 0 {
+		tflog.SubsystemTrace(ctx, logSubsystem, "sending request body", map[string]interface{}{
+			"request_body": redactBody(body),
+		})
+	}
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	ctx = tflog.SubsystemSetField(ctx, logSubsystem, "duration_ms", time.Since(start).Milliseconds())
+	if err != nil {
+		tflog.SubsystemDebug(ctx, logSubsystem, "request to Resend API failed", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return nil, err
+	}
+
+	// The body is read here, so it can be logged, and handed on in memory.
+	body, err = io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	tflog.SubsystemDebug(ctx, logSubsystem, "received response from Resend API", map[string]interface{}{
+		"status": resp.StatusCode,
+	})
+	tflog.SubsystemTrace(ctx, logSubsystem, "received response body", map[string]interface{}{
+		"response_body": redactBody(body),
+	})
+
+	return resp, nil
+}
+
+// redactBody replaces the values of sensitive keys in a JSON body. Bodies that
+// are not JSON are returned as they are and only masked by secretPatterns.
+func redactBody(body []byte) string {
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return string(body)
+	}
+
+	out, err := json.Marshal(redactValue(v))
+	if err != nil {
+		return string(body)
+	}
+
+	return string(out)
+}
+
+func redactValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if isSensitiveLogKey(key) {
+				v[key] = redacted
+				continue
+			}
+			v[key] = redactValue(value)
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = redactValue(value)
+		}
+	}
+
+	return v
+}
+
+func isSensitiveLogKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, sensitive := range sensitiveLogKeys {
+		if key == sensitive {
+			return true
+		}
+	}
+
+	return false
+}
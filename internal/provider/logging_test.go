@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/resend/resend-go/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientLogsRequestsWithoutSecrets(t *testing.T) {
+	t.Setenv("TF_LOG_PROVIDER_RESEND_API", "TRACE")
+	_, client := newTestClient(t, clientConfig{})
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	key, err := client.ApiKeys.CreateWithContext(ctx, &resend.CreateApiKeyRequest{Name: "test"})
+	require.NoError(t, err)
+	require.NotEmpty(t, key.Token)
+
+	logs := output.String()
+	require.NotContains(t, logs, key.Token)
+	require.NotContains(t, logs, "re_test")
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	require.NoError(t, err)
+
+	var messages []string
+	for _, entry := range entries {
+		messages = append(messages, entry["@message"].(string))
+		if entry["@message"] == "received response from Resend API" {
+			require.Equal(t, "POST", entry["method"])
+			require.Equal(t, "/api-keys", entry["path"])
+			require.EqualValues(t, 201, entry["status"])
+		}
+	}
+	require.Contains(t, messages, "sending request body")
+	require.Contains(t, messages, "received response from Resend API")
+	require.Contains(t, messages, "received response body")
+}
+
+func TestRedactBody(t *testing.T) {
+	require.JSONEq(t,
+		`{"id":"1","token":"***","data":[{"signing_secret":"***","name":"a"}]}`,
+		redactBody([]byte(`{"id":"1","token":"re_123","data":[{"signing_secret":"whsec_abc","name":"a"}]}`)),
+	)
+	require.Equal(t, "not json", redactBody([]byte("not json")))
+}
+
+func TestLogMaskingPatterns(t *testing.T) {
+	var output bytes.Buffer
+	ctx := withLogMasking(tflogtest.RootLogger(context.Background(), &output))
+
+	tflog.Info(ctx, "created key re_123456789abc for pre_processing_queue, secret whsec_c2VjcmV0 of webhook_whsec_config")
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, "created key *** for pre_processing_queue, secret *** of webhook_whsec_config", entries[0]["@message"])
+}
+
+func TestClientLogMasking(t *testing.T) {
+	_, client := newTestClient(t, clientConfig{})
+
+	var output bytes.Buffer
+	ctx := withClientLogMasking(tflogtest.RootLogger(context.Background(), &output), client)
+
+	tflog.Info(ctx, "configured with key re_test")
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, "configured with key ***", entries[0]["@message"])
+}
+
+func TestMaskSecrets(t *testing.T) {
+	require.Equal(t,
+		"API key *** is invalid, got *** from ***",
+		maskSecrets("API key re_123456789abc is invalid, got Bearer abc from secret", "secret", ""),
+	)
+}
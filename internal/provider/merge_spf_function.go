@@ -0,0 +1,211 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &MergeSpfFunction{}
+
+func NewMergeSpfFunction() function.Function {
+	return &MergeSpfFunction{}
+}
+
+// MergeSpfFunction defines the function implementation.
+type MergeSpfFunction struct{}
+
+// spfMaxLookups is the maximum number of DNS lookups of an SPF record, see
+// RFC 7208 section 4.6.4.
+const spfMaxLookups = 10
+
+// spfMechanisms are the names of the SPF mechanisms other than all, see
+// RFC 7208 section 5.
+var spfMechanisms = []string{"a", "mx", "ptr", "exists", "ip4", "ip6", "include"}
+
+// spfDomainPattern matches a domain name with at least two labels, e.g.
+// amazonses.com or _spf.example.com.
+var spfDomainPattern = regexp.MustCompile(`^([A-Za-z0-9_]([A-Za-z0-9_-]*[A-Za-z0-9])?\.)+[A-Za-z]([A-Za-z0-9-]*[A-Za-z0-9])?\.?$`)
+
+func (f *MergeSpfFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "merge_spf"
+}
+
+func (f *MergeSpfFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Merge the SPF requirements of Resend into an existing SPF record",
+		MarkdownDescription: "Adds the mechanisms required by Resend to an existing SPF record. Mechanisms that are already part of the record are not added again, " +
+			"the `all` mechanism of the existing record stays last and modifiers such as `redirect=` follow it. " +
+			"Fails if the merged record needs more than 10 DNS lookups, the limit of [RFC 7208](https://datatracker.ietf.org/doc/html/rfc7208#section-4.6.4). Only the lookups of the record itself are counted, not the ones of included records.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "existing",
+				MarkdownDescription: "The existing SPF record, e.g. `v=spf1 include:_spf.google.com -all`, with or without quotes. An empty string creates a new record that ends with `~all`.",
+			},
+			function.StringParameter{
+				Name:                "resend_include",
+				MarkdownDescription: "The SPF requirements of Resend, either the domain to include, e.g. `amazonses.com`, a mechanism such as `include:amazonses.com` or the whole SPF record of `resend_domain`, e.g. `\"v=spf1 include:amazonses.com ~all\"`. Terms that are neither a mechanism nor a domain name are rejected.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *MergeSpfFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	ctx = withLogMasking(ctx)
+
+	var existing, resendInclude string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &existing, &resendInclude))
+	if resp.Error != nil {
+		return
+	}
+
+	record, err := mergeSpf(existing, resendInclude)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, record))
+}
+
+// spfRecord is a parsed SPF record.
+type spfRecord struct {
+	// mechanisms are the mechanisms of the record, without all.
+	mechanisms []string
+	// all is the all mechanism with its qualifier, e.g. ~all, if any.
+	all string
+	// modifiers are the modifiers of the record, e.g. redirect=example.com.
+	modifiers []string
+}
+
+// parseSpf parses an SPF record with or without surrounding quotes.
+func parseSpf(value string) (spfRecord, error) {
+	terms := strings.Fields(txtValue(strings.TrimSpace(value)))
+	if len(terms) == 0 || !strings.EqualFold(terms[0], "v=spf1") {
+		return spfRecord{}, fmt.Errorf("%q is not an SPF record, it must start with v=spf1", value)
+	}
+
+	var record spfRecord
+	for _, term := range terms[1:] {
+		name := spfTermName(term)
+		switch {
+		case name == "all":
+			record.all = term
+		case strings.Contains(term, "=") && !strings.ContainsAny(term[:strings.Index(term, "=")], ":/"):
+			record.modifiers = append(record.modifiers, term)
+		default:
+			record.mechanisms = append(record.mechanisms, term)
+		}
+	}
+
+	return record, nil
+}
+
+// spfTermName returns the lower case name of a mechanism or modifier without
+// qualifier and value, e.g. include for ~include:example.com.
+func spfTermName(term string) string {
+	term = strings.TrimLeft(term, "+-~?")
+	if i := strings.IndexAny(term, ":/="); i >= 0 {
+		term = term[:i]
+	}
+
+	return strings.ToLower(term)
+}
+
+// spfTermKey returns the key used to find duplicate terms. The + qualifier is
+// the default and names are case-insensitive.
+func spfTermKey(term string) string {
+	return strings.ToLower(strings.TrimPrefix(term, "+"))
+}
+
+// spfLookups returns the number of DNS lookups needed to evaluate the terms.
+func spfLookups(terms []string) int {
+	lookups := 0
+	for _, term := range terms {
+		switch spfTermName(term) {
+		case "include", "a", "mx", "ptr", "exists", "redirect":
+			lookups++
+		}
+	}
+
+	return lookups
+}
+
+// mergeSpf adds the mechanisms of resendInclude to the existing SPF record.
+func mergeSpf(existing, resendInclude string) (string, error) {
+	record := spfRecord{all: "~all"}
+	if strings.TrimSpace(existing) != "" {
+		var err error
+		record, err = parseSpf(existing)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	var additions []string
+	resendInclude = strings.TrimSpace(txtValue(strings.TrimSpace(resendInclude)))
+	switch {
+	case resendInclude == "":
+		return "", fmt.Errorf("resend_include must not be empty")
+	case strings.HasPrefix(strings.ToLower(resendInclude), "v=spf1"):
+		resend, err := parseSpf(resendInclude)
+		if err != nil {
+			return "", err
+		}
+		additions = resend.mechanisms
+	default:
+		// Mechanisms may be copied from an SPF record with its all
+		// mechanism, which must not be added a second time. Domain names
+		// are included, mechanisms without a value such as mx are kept.
+		for _, term := range strings.Fields(resendInclude) {
+			name := spfTermName(term)
+			switch {
+			case name == "all":
+				continue
+			case slices.Contains(spfMechanisms, name):
+			case spfDomainPattern.MatchString(term):
+				term = "include:" + term
+			default:
+				return "", fmt.Errorf("resend_include must contain SPF mechanisms or domain names, got %q", term)
+			}
+			additions = append(additions, term)
+		}
+		if len(additions) == 0 {
+			return "", fmt.Errorf("resend_include must contain a mechanism other than all, got %q", resendInclude)
+		}
+	}
+
+	seen := map[string]bool{}
+	for _, term := range record.mechanisms {
+		seen[spfTermKey(term)] = true
+	}
+	for _, term := range additions {
+		if seen[spfTermKey(term)] {
+			continue
+		}
+		seen[spfTermKey(term)] = true
+		record.mechanisms = append(record.mechanisms, term)
+	}
+
+	terms := append([]string{"v=spf1"}, record.mechanisms...)
+	if record.all != "" {
+		terms = append(terms, record.all)
+	}
+	terms = append(terms, record.modifiers...)
+
+	if lookups := spfLookups(terms); lookups > spfMaxLookups {
+		return "", fmt.Errorf("the merged SPF record needs %d DNS lookups, more than the limit of %d: %s", lookups, spfMaxLookups, strings.Join(terms, " "))
+	}
+
+	return strings.Join(terms, " "), nil
+}
@@ -0,0 +1,149 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeSpf(t *testing.T) {
+	tests := map[string]struct {
+		existing      string
+		resendInclude string
+		want          string
+		err           string
+	}{
+		"new record": {
+			existing:      "",
+			resendInclude: "amazonses.com",
+			want:          "v=spf1 include:amazonses.com ~all",
+		},
+		"all stays last": {
+			existing:      "v=spf1 include:_spf.google.com -all",
+			resendInclude: "include:amazonses.com",
+			want:          "v=spf1 include:_spf.google.com include:amazonses.com -all",
+		},
+		"resend record": {
+			existing:      `"v=spf1 ip4:192.0.2.0/24 ?all"`,
+			resendInclude: `"v=spf1 include:amazonses.com ~all"`,
+			want:          "v=spf1 ip4:192.0.2.0/24 include:amazonses.com ?all",
+		},
+		"include with all": {
+			existing:      "v=spf1 include:_spf.google.com -all",
+			resendInclude: "include:amazonses.com ~all",
+			want:          "v=spf1 include:_spf.google.com include:amazonses.com -all",
+		},
+		"domain with all": {
+			existing:      "",
+			resendInclude: "amazonses.com -all",
+			want:          "v=spf1 include:amazonses.com ~all",
+		},
+		"mechanism without value": {
+			existing:      "v=spf1 include:_spf.google.com -all",
+			resendInclude: "mx",
+			want:          "v=spf1 include:_spf.google.com mx -all",
+		},
+		"a mechanism": {
+			existing:      "",
+			resendInclude: "a amazonses.com",
+			want:          "v=spf1 a include:amazonses.com ~all",
+		},
+		"qualified mechanism": {
+			existing:      "v=spf1 -all",
+			resendInclude: "~include:x",
+			want:          "v=spf1 ~include:x -all",
+		},
+		"not a domain": {
+			existing:      "v=spf1 -all",
+			resendInclude: "amazonses",
+			err:           `resend_include must contain SPF mechanisms or domain names, got "amazonses"`,
+		},
+		"unknown mechanism": {
+			existing:      "v=spf1 -all",
+			resendInclude: "foo:amazonses.com",
+			err:           `got "foo:amazonses.com"`,
+		},
+		"modifier": {
+			existing:      "v=spf1 -all",
+			resendInclude: "redirect=amazonses.com",
+			err:           `got "redirect=amazonses.com"`,
+		},
+		"only all": {
+			existing:      "v=spf1 -all",
+			resendInclude: "~all",
+			err:           "resend_include must contain a mechanism other than all",
+		},
+		"duplicates": {
+			existing:      "v=spf1 +include:AmazonSES.com mx ~all",
+			resendInclude: "amazonses.com",
+			want:          "v=spf1 +include:AmazonSES.com mx ~all",
+		},
+		"modifiers follow all": {
+			existing:      "v=spf1 mx redirect=_spf.example.com",
+			resendInclude: "amazonses.com",
+			want:          "v=spf1 mx include:amazonses.com redirect=_spf.example.com",
+		},
+		"lookup limit": {
+			existing:      "v=spf1 a mx ptr include:a.example.com include:b.example.com include:c.example.com include:d.example.com include:e.example.com include:f.example.com exists:%{i}.example.com -all",
+			resendInclude: "amazonses.com",
+			err:           "needs 11 DNS lookups, more than the limit of 10",
+		},
+		"ip mechanisms need no lookups": {
+			existing:      "v=spf1 a mx include:a.example.com include:b.example.com include:c.example.com include:d.example.com include:e.example.com include:f.example.com exists:%{i}.example.com ip4:192.0.2.1 ip6:2001:db8::1 -all",
+			resendInclude: "amazonses.com",
+			want:          "v=spf1 a mx include:a.example.com include:b.example.com include:c.example.com include:d.example.com include:e.example.com include:f.example.com exists:%{i}.example.com ip4:192.0.2.1 ip6:2001:db8::1 include:amazonses.com -all",
+		},
+		"not spf": {
+			existing:      "google-site-verification=abc",
+			resendInclude: "amazonses.com",
+			err:           "is not an SPF record",
+		},
+		"empty include": {
+			existing:      "v=spf1 -all",
+			resendInclude: "",
+			err:           "resend_include must not be empty",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := mergeSpf(test.existing, test.resendInclude)
+			if test.err != "" {
+				require.ErrorContains(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestAccMergeSpfFunction(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+output "test" {
+  value = provider::resend::merge_spf("v=spf1 include:_spf.google.com -all", "amazonses.com")
+}
+`,
+				Check: resource.TestCheckOutput("test", "v=spf1 include:_spf.google.com include:amazonses.com -all"),
+			},
+			{
+				Config: `
+output "test" {
+  value = provider::resend::merge_spf("v=spf1 -all", "")
+}
+`,
+				ExpectError: regexp.MustCompile(`resend_include\s+must\s+not\s+be\s+empty`),
+			},
+		},
+	})
+}
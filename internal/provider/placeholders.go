@@ -0,0 +1,141 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// placeholderKeyPattern matches the keys of variables in placeholders.
+var placeholderKeyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// placeholder is a {{{KEY}}} or {{{KEY|fallback}}} placeholder in a subject,
+// HTML or text.
+type placeholder struct {
+	Key  string
+	Line int
+}
+
+// placeholderError is a malformed placeholder.
+type placeholderError struct {
+	Line    int
+	Message string
+}
+
+// parsePlaceholders returns the placeholders in s in the order they appear
+// and the placeholders that are malformed.
+func parsePlaceholders(s string) ([]placeholder, []placeholderError) {
+	var placeholders []placeholder
+	var errs []placeholderError
+
+	for i := 0; ; {
+		start := strings.Index(s[i:], "{{{")
+		if start < 0 {
+			break
+		}
+		start += i
+		line := lineOf(s, start)
+
+		end := strings.Index(s[start+3:], "}}}")
+		if end < 0 {
+			errs = append(errs, placeholderError{Line: line, Message: fmt.Sprintf("%s is not closed with }}}", snippet(s[start:]))})
+			break
+		}
+		inner := s[start+3 : start+3+end]
+
+		if next := strings.Index(inner, "{{{"); next >= 0 {
+			errs = append(errs, placeholderError{Line: line, Message: fmt.Sprintf("%s is not closed with }}}", snippet(s[start:start+3+next]))})
+			i = start + 3 + next
+			continue
+		}
+		i = start + 3 + end + 3
+
+		key, _, _ := strings.Cut(inner, "|")
+		key = strings.TrimSpace(key)
+		if !placeholderKeyPattern.MatchString(key) {
+			errs = append(errs, placeholderError{Line: line, Message: fmt.Sprintf("%s has an invalid variable name %q", s[start:i], key)})
+			continue
+		}
+		placeholders = append(placeholders, placeholder{Key: key, Line: line})
+	}
+
+	for _, m := range doubleBraces(s) {
+		errs = append(errs, placeholderError{Line: lineOf(s, m[0]), Message: fmt.Sprintf("%s must use three braces, e.g. {{{KEY}}}", s[m[0]:m[1]])})
+	}
+
+	return placeholders, errs
+}
+
+// doubleBraces returns the start and end offsets of the placeholders in s
+// that use two braces instead of three, e.g. {{KEY}}, which Resend sends as
+// they are. Only the braces of a placeholder are looked at, so placeholders
+// right next to each other are all found.
+func doubleBraces(s string) [][2]int {
+	var matches [][2]int
+	for i := 0; ; {
+		start := strings.Index(s[i:], "{{")
+		if start < 0 {
+			break
+		}
+		start += i
+
+		// Runs of three or more braces are parsed as placeholders.
+		end := start
+		for end < len(s) && s[end] == '{' {
+			end++
+		}
+		i = end
+		if end-start != 2 {
+			continue
+		}
+
+		length := strings.Index(s[end:], "}}")
+		if length < 0 {
+			continue
+		}
+		closeEnd := end + length + 2
+		if !placeholderKeyPattern.MatchString(strings.TrimSpace(s[end:end+length])) || (closeEnd < len(s) && s[closeEnd] == '}') {
+			continue
+		}
+		matches = append(matches, [2]int{start, closeEnd})
+	}
+
+	return matches
+}
+
+// placeholdersOf returns the placeholders of an attribute and adds errors for
+// the malformed ones to diags. It returns nil for null and unknown values.
+func placeholdersOf(p path.Path, value types.String, diags *diag.Diagnostics) []placeholder {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+
+	placeholders, errs := parsePlaceholders(value.ValueString())
+	for _, err := range errs {
+		diags.AddAttributeError(p, "Malformed Placeholder", fmt.Sprintf("Line %d: %s.", err.Line, err.Message))
+	}
+
+	return placeholders
+}
+
+// lineOf returns the line number of the byte offset i in s.
+func lineOf(s string, i int) int {
+	return strings.Count(s[:i], "\n") + 1
+}
+
+// snippet returns the beginning of s for error messages.
+func snippet(s string) string {
+	s, _, _ = strings.Cut(s, "\n")
+	if r := []rune(s); len(r) > 20 {
+		return string(r[:20]) + "..."
+	}
+
+	return s
+}
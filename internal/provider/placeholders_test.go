@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePlaceholders(t *testing.T) {
+	placeholders, errs := parsePlaceholders("<p>Hi {{{FIRST_NAME|there}}},</p>\n<p>{{{ CREDITS }}} credits left.</p>\n<a href=\"{{{RESEND_UNSUBSCRIBE_URL}}}\">Unsubscribe</a>")
+	require.Empty(t, errs)
+	require.Equal(t, []placeholder{
+		{Key: "FIRST_NAME", Line: 1},
+		{Key: "CREDITS", Line: 2},
+		{Key: "RESEND_UNSUBSCRIBE_URL", Line: 3},
+	}, placeholders)
+
+	placeholders, errs = parsePlaceholders("<style>p {{ color: red }}</style>")
+	require.Empty(t, placeholders)
+	require.Empty(t, errs)
+}
+
+func TestParsePlaceholdersMalformed(t *testing.T) {
+	tests := map[string]placeholderError{
+		"Hi {{{NAME}}":                 {Line: 1, Message: "{{{NAME}} is not closed with }}}"},
+		"Hi {{{NAME\n{{{EMAIL}}}":      {Line: 1, Message: "{{{NAME is not closed with }}}"},
+		"Hi {{{}}}":                    {Line: 1, Message: `{{{}}} has an invalid variable name ""`},
+		"\nHi {{{FIRST NAME}}}":        {Line: 2, Message: `{{{FIRST NAME}}} has an invalid variable name "FIRST NAME"`},
+		"Hi {{{1ST}}}":                 {Line: 1, Message: `{{{1ST}}} has an invalid variable name "1ST"`},
+		"Hi {{NAME}}":                  {Line: 1, Message: "{{NAME}} must use three braces, e.g. {{{KEY}}}"},
+		"Hi {{{NAME}}}\n\n{{ EMAIL }}": {Line: 3, Message: "{{ EMAIL }} must use three braces, e.g. {{{KEY}}}"},
+	}
+
+	for s, want := range tests {
+		_, errs := parsePlaceholders(s)
+		require.Equal(t, []placeholderError{want}, errs, s)
+	}
+}
+
+func TestParsePlaceholdersAdjacentDoubleBraces(t *testing.T) {
+	placeholders, errs := parsePlaceholders("{{A}}{{B}} {{{C}}}{{D}}\n{{E}}")
+	require.Equal(t, []placeholder{{Key: "C", Line: 1}}, placeholders)
+	require.Equal(t, []placeholderError{
+		{Line: 1, Message: "{{A}} must use three braces, e.g. {{{KEY}}}"},
+		{Line: 1, Message: "{{B}} must use three braces, e.g. {{{KEY}}}"},
+		{Line: 1, Message: "{{D}} must use three braces, e.g. {{{KEY}}}"},
+		{Line: 2, Message: "{{E}} must use three braces, e.g. {{{KEY}}}"},
+	}, errs)
+}
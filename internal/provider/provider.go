@@ -6,21 +6,28 @@ package provider
 import (
 	"context"
 	"fmt"
-	"log"
 	"os"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"github.com/resendlabs/resend-go"
 )
 
 // Ensure ResendProvider satisfies various provider interfaces.
 var _ provider.Provider = &ResendProvider{}
+var _ provider.ProviderWithFunctions = &ResendProvider{}
 
 // ResendProvider defines the provider implementation.
 type ResendProvider struct {
@@ -32,7 +39,16 @@ type ResendProvider struct {
 
 // ResendProviderModel describes the provider data model.
 type ResendProviderModel struct {
-	ApiKey types.String `tfsdk:"api_key"`
+	ApiKey             types.String  `tfsdk:"api_key"`
+	ApiKeyFile         types.String  `tfsdk:"api_key_file"`
+	ApiKeyCommand      types.List    `tfsdk:"api_key_command"`
+	Profile            types.String  `tfsdk:"profile"`
+	BaseUrl            types.String  `tfsdk:"base_url"`
+	Timeout            types.String  `tfsdk:"timeout"`
+	InsecureSkipVerify types.Bool    `tfsdk:"insecure_skip_verify"`
+	MaxRetries         types.Int64   `tfsdk:"max_retries"`
+	RetryMaxWait       types.String  `tfsdk:"retry_max_wait"`
+	RequestsPerSecond  types.Float64 `tfsdk:"requests_per_second"`
 }
 
 func (p *ResendProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -44,9 +60,71 @@ func (p *ResendProvider) Schema(ctx context.Context, req provider.SchemaRequest,
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"api_key": schema.StringAttribute{
-				MarkdownDescription: "A resend API key",
-				Required:            true,
+				MarkdownDescription: "A Resend API key. Can also be set with the `RESEND_API_KEY` environment variable, or read with `api_key_file`, `api_key_command` or `profile`.",
+				Optional:            true,
 				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("api_key_file"), path.MatchRoot("api_key_command")),
+				},
+			},
+			"api_key_file": schema.StringAttribute{
+				MarkdownDescription: "The path of a file that contains the Resend API key, e.g. one rendered by Vault Agent. Surrounding whitespace is removed. Conflicts with `api_key` and `api_key_command`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("api_key_command")),
+				},
+			},
+			"api_key_command": schema.ListAttribute{
+				MarkdownDescription: "A command that prints the Resend API key, as a list of the program and its arguments, e.g. `[\"op\", \"read\", \"op://vault/resend/credential\"]`. The command is run without a shell. Conflicts with `api_key` and `api_key_file`.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+			"profile": schema.StringAttribute{
+				MarkdownDescription: "The name of a profile in the credentials file `~/.config/resend/credentials` (`$XDG_CONFIG_HOME/resend/credentials` if set) to read the API key and base URL from. The file is TOML with a `[profile]` table per profile and `api_key` and `base_url` keys, INI files with plain values are read as well. Can also be set with the `RESEND_PROFILE` environment variable. Conflicts with `api_key`, `api_key_file` and `api_key_command`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.ConflictsWith(path.MatchRoot("api_key"), path.MatchRoot("api_key_file"), path.MatchRoot("api_key_command")),
+				},
+			},
+			"base_url": schema.StringAttribute{
+				MarkdownDescription: "The base URL of the Resend API, e.g. to go through a proxy. Can also be set with the `RESEND_BASE_URL` environment variable. Defaults to `https://api.resend.com/`.",
+				Optional:            true,
+			},
+			"timeout": schema.StringAttribute{
+				MarkdownDescription: "The timeout of a single request to the Resend API, as a duration such as `30s`. Defaults to `1m`.",
+				Optional:            true,
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				MarkdownDescription: "Skip the verification of the TLS certificate of the Resend API. Only use this for testing, e.g. with a local fake of the API.",
+				Optional:            true,
+			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: "How often a request is retried when it is rate limited or fails with a server error. Set to `0` to disable retries. Defaults to `3`.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"retry_max_wait": schema.StringAttribute{
+				MarkdownDescription: "The longest time to wait before retrying a request, as a duration such as `30s`. It also caps the `Retry-After` header of rate limited responses. Defaults to `30s`.",
+				Optional:            true,
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			"requests_per_second": schema.Float64Attribute{
+				MarkdownDescription: "The maximum number of requests per second sent to the Resend API, shared by all resources and data sources of the provider. Defaults to `2`, the default rate limit of a Resend account.",
+				Optional:            true,
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0.1),
+				},
 			},
 		},
 	}
@@ -69,14 +147,81 @@ func (p *ResendProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		)
 	}
 
+	if config.ApiKeyFile.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("api_key_file"),
+			"Unknown API key file",
+			"The provider cannot create the Resend API client if the path of the API key file is unknown. "+
+				"Either target apply the source of the value first, or set the value statically in the configuration.",
+		)
+	}
+
+	if isListUnknown(config.ApiKeyCommand) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("api_key_command"),
+			"Unknown API key command",
+			"The provider cannot create the Resend API client if the API key command is unknown. "+
+				"Either target apply the source of the value first, or set the value statically in the configuration.",
+		)
+	}
+
+	if config.Profile.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("profile"),
+			"Unknown profile",
+			"The provider cannot create the Resend API client if the profile is unknown. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the RESEND_PROFILE environment variable.",
+		)
+	}
+
+	if config.BaseUrl.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("base_url"),
+			"Unknown base URL",
+			"The provider cannot create the Resend API client if the base URL is unknown. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the RESEND_BASE_URL environment variable.",
+		)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A profile is only used when the API key is not set in the configuration,
+	// e.g. if RESEND_PROFILE is set for all providers, but one sets api_key.
+	var profile *credentialsProfile
+	if config.ApiKey.IsNull() && config.ApiKeyFile.IsNull() && config.ApiKeyCommand.IsNull() {
+		profile = resolveProfile(config, &resp.Diagnostics)
+	}
+
+	clientConfig := clientConfig{
+		BaseURL:            os.Getenv("RESEND_BASE_URL"),
+		InsecureSkipVerify: config.InsecureSkipVerify.ValueBool(),
+	}
+	if profile != nil && profile.BaseURL != "" {
+		clientConfig.BaseURL = profile.BaseURL
+	}
+	if !config.BaseUrl.IsNull() {
+		clientConfig.BaseURL = config.BaseUrl.ValueString()
+	}
+	clientConfig.Timeout = parseDuration(config.Timeout, path.Root("timeout"), &resp.Diagnostics)
+	clientConfig.RetryMaxWait = parseDuration(config.RetryMaxWait, path.Root("retry_max_wait"), &resp.Diagnostics)
+	clientConfig.MaxRetries = defaultMaxRetries
+	if !config.MaxRetries.IsNull() {
+		clientConfig.MaxRetries = int(config.MaxRetries.ValueInt64())
+	}
+	clientConfig.RequestsPerSecond = defaultRequestsPerSecond
+	if !config.RequestsPerSecond.IsNull() {
+		clientConfig.RequestsPerSecond = config.RequestsPerSecond.ValueFloat64()
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	log.Println(os.Environ())
 
-	apiKey := os.Getenv("RESEND_API_KEY")
-	if !config.ApiKey.IsNull() {
-		apiKey = config.ApiKey.ValueString()
+	apiKey := resolveApiKey(ctx, config, profile, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	if apiKey == "" {
@@ -84,27 +229,75 @@ func (p *ResendProvider) Configure(ctx context.Context, req provider.ConfigureRe
 			path.Root("api_key"),
 			"Missing api key",
 			"The provider cannot create the Resend API client as there is a missing or empty value for the Resend api key. "+
-				"Set the api_key value in the configuration or use the RESEND_API_KEY environment variable. "+
+				"Set the api_key, api_key_file, api_key_command or profile value in the configuration or use the RESEND_API_KEY environment variable. "+
 				"If either is already set, ensure the value is not empty.",
 		)
 
 		return
 	}
-	tflog.Info(ctx, fmt.Sprintf("Creating Resend API client %s", apiKey))
-	client := resend.NewClient(config.ApiKey.ValueString())
+	ctx = withLogMasking(ctx, apiKey)
+	tflog.Info(ctx, "Creating Resend API client", map[string]interface{}{
+		"base_url": clientConfig.BaseURL,
+	})
+	client, err := newClient(apiKey, clientConfig)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("base_url"), "Invalid base URL", err.Error())
+		return
+	}
 	resp.DataSourceData = client
 	resp.ResourceData = client
 }
 
+// parseDuration parses an optional duration attribute of the provider. It
+// returns zero if the attribute is not set, so the client uses its default.
+func parseDuration(value types.String, p path.Path, diags *diag.Diagnostics) time.Duration {
+	if value.IsNull() || value.IsUnknown() {
+		return 0
+	}
+
+	d, err := time.ParseDuration(value.ValueString())
+	if err != nil || d <= 0 {
+		diags.AddAttributeError(
+			p,
+			"Invalid duration",
+			fmt.Sprintf("The value must be a positive duration such as 30s, got: %q", value.ValueString()),
+		)
+		return 0
+	}
+
+	return d
+}
+
 func (p *ResendProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewDomainResource,
+		NewDomainVerificationResource,
 		NewApiKeyResource,
+		NewAudienceResource,
+		NewContactResource,
+		NewAudienceContactsResource,
+		NewBroadcastResource,
+		NewWebhookResource,
+		NewTemplateResource,
 	}
 }
 
 func (p *ResendProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{}
+	return []func() datasource.DataSource{
+		NewDomainDataSource,
+		NewDomainsDataSource,
+		NewApiKeysDataSource,
+	}
+}
+
+func (p *ResendProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewRecordsToZonefileFunction,
+		NewRecordsToRoute53ChangesFunction,
+		NewRecordsToCloudflareFunction,
+		NewMergeSpfFunction,
+		NewDmarcRecordFunction,
+	}
 }
 
 func New(version string) func() provider.Provider {
@@ -7,17 +7,54 @@ import (
 	"fmt"
 	"os"
 	"testing"
+	"time"
 
+	"github.com/chronark/terraform-provider-resend/internal/resendfake"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/resend/resend-go/v3"
 	"github.com/stretchr/testify/require"
 )
 
-var providerConfig = fmt.Sprintf(`
+// providerConfig configures the provider for acceptance testing. The API key
+// of the account is read from RESEND_API_KEY. It is set by TestMain.
+var providerConfig string
+
+// testAccFake is the fake Resend API the acceptance tests run against when no
+// RESEND_API_KEY is set. It is nil when the tests run against the real API.
+var testAccFake *resendfake.Server
+
+func TestMain(m *testing.M) {
+	if os.Getenv("RESEND_API_KEY") == "" {
+		testAccFake = resendfake.NewServer()
+		os.Setenv("RESEND_API_KEY", "re_test")
+		os.Setenv("RESEND_BASE_URL", testAccFake.URL)
+
+		// The fake finishes the verification of a domain right away.
+		domainVerificationPollInterval = 100 * time.Millisecond
+		domainVerificationFirstPollDelay = 10 * time.Millisecond
+	}
+
+	// The fake has no rate limit.
+	requestsPerSecond := 2
+	if testAccFake != nil {
+		requestsPerSecond = 100
+	}
+
+	providerConfig = fmt.Sprintf(`
 provider "resend" {
-  api_key = "%s"
+  requests_per_second = %d
+}
+`, requestsPerSecond)
+
+	code := m.Run()
+
+	if testAccFake != nil {
+		testAccFake.Close()
+	}
+	os.Exit(code)
 }
-`, os.Getenv("RESEND_API_KEY"))
 
 // testAccProtoV6ProviderFactories are used to instantiate a provider during
 // acceptance testing. The factory function will be invoked for every Terraform
@@ -33,3 +70,41 @@ func TestAccPreCheck(t *testing.T) {
 	// function.
 	require.NotEmpty(t, os.Getenv("RESEND_API_KEY"))
 }
+
+// testAccClient returns a client for the account used during acceptance
+// testing, e.g. to change objects outside of Terraform.
+func testAccClient() *resend.Client {
+	client, err := newClient(os.Getenv("RESEND_API_KEY"), clientConfig{BaseURL: os.Getenv("RESEND_BASE_URL")})
+	if err != nil {
+		panic(err)
+	}
+	return client
+}
+
+// testAccCaptureAttr stores the value of an attribute of a resource in the
+// state, so it can be used in a later test step.
+func testAccCaptureAttr(name, key string, value *string) func(*terraform.State) error {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", name)
+		}
+		*value = rs.Primary.Attributes[key]
+		return nil
+	}
+}
+
+// testAccCheckAttrChanged checks that an attribute no longer has the value
+// captured by testAccCaptureAttr.
+func testAccCheckAttrChanged(name, key string, old *string) func(*terraform.State) error {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", name)
+		}
+		if rs.Primary.Attributes[key] == *old {
+			return fmt.Errorf("expected %s.%s to change, still %q", name, key, *old)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &RecordsToCloudflareFunction{}
+
+func NewRecordsToCloudflareFunction() function.Function {
+	return &RecordsToCloudflareFunction{}
+}
+
+// RecordsToCloudflareFunction defines the function implementation.
+type RecordsToCloudflareFunction struct{}
+
+// cloudflareRecord is a DNS record in the format of the Cloudflare API.
+type cloudflareRecord struct {
+	Type     string `json:"type"`
+	Name     string `json:"name"`
+	Content  string `json:"content"`
+	TTL      int64  `json:"ttl"`
+	Priority *int64 `json:"priority,omitempty"`
+}
+
+// cloudflareAutoTtl is the TTL that lets Cloudflare choose the TTL.
+const cloudflareAutoTtl = 1
+
+func (f *RecordsToCloudflareFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "records_to_cloudflare"
+}
+
+func (f *RecordsToCloudflareFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Render DNS records in the format of the Cloudflare API",
+		MarkdownDescription: "Renders DNS records, such as the ones required to verify a domain, as a JSON array of records in the format of the Cloudflare DNS records API, with `type`, `name`, `content`, `ttl` and `priority` attributes. " +
+			"Names are fully qualified, TXT values are not quoted, MX records have a separate `priority` and records with an `Auto` TTL get the automatic TTL `1` of Cloudflare. Decode the result with `jsondecode` to use it with the Cloudflare provider.",
+		Parameters: []function.Parameter{
+			recordsParameter(),
+			zoneParameter("zone"),
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *RecordsToCloudflareFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	ctx = withLogMasking(ctx)
+
+	var records []DnsRecord
+	var zone string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &records, &zone))
+	if resp.Error != nil {
+		return
+	}
+
+	result, err := recordsToCloudflare(records, zone)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, result))
+}
+
+// recordsToCloudflare renders records in the format of the Cloudflare API.
+func recordsToCloudflare(records []DnsRecord, zone string) (string, error) {
+	zone, err := normalizeZone(zone)
+	if err != nil {
+		return "", err
+	}
+
+	result := []cloudflareRecord{}
+	for _, record := range records {
+		ttl, ok, err := recordTtl(record)
+		if err != nil {
+			return "", err
+		}
+		if !ok {
+			ttl = cloudflareAutoTtl
+		}
+
+		r := cloudflareRecord{
+			Type:    strings.ToUpper(record.Type.ValueString()),
+			Name:    strings.ToLower(recordFqdn(record.Name.ValueString(), zone)),
+			Content: record.Value.ValueString(),
+			TTL:     ttl,
+		}
+
+		switch r.Type {
+		case "TXT":
+			// Cloudflare quotes and splits the content itself.
+			r.Content = txtValue(r.Content)
+		case "MX":
+			if record.Priority.IsNull() {
+				return "", fmt.Errorf("the MX record %s has no priority", record.Name.ValueString())
+			}
+			r.Priority = record.Priority.ValueInt64Pointer()
+		case "":
+			return "", fmt.Errorf("the record %s has no type", record.Name.ValueString())
+		}
+
+		result = append(result, r)
+	}
+
+	b, err := json.Marshal(result)
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
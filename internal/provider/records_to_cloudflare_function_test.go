@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccRecordsToCloudflareFunction(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRecords + `
+output "test" {
+  value = provider::resend::records_to_cloudflare(local.records, "example.com")
+}
+`,
+				Check: resource.TestCheckOutput("test", `[`+
+					`{"type":"MX","name":"send.example.com","content":"feedback-smtp.us-east-1.amazonses.com","ttl":1,"priority":10},`+
+					`{"type":"TXT","name":"send.example.com","content":"v=spf1 include:amazonses.com ~all","ttl":1},`+
+					`{"type":"TXT","name":"resend._domainkey.example.com","content":"p=`+strings.Repeat("A", 300)+`","ttl":300}`+
+					`]`),
+			},
+			{
+				Config: `
+output "test" {
+  value = provider::resend::records_to_cloudflare([{
+    name     = "send"
+    type     = "MX"
+    value    = "feedback-smtp.us-east-1.amazonses.com"
+    ttl      = "Auto"
+    priority = null
+  }], "example.com")
+}
+`,
+				ExpectError: regexp.MustCompile(`the MX\s+record send has no priority`),
+			},
+		},
+	})
+}
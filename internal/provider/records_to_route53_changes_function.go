@@ -0,0 +1,136 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &RecordsToRoute53ChangesFunction{}
+
+func NewRecordsToRoute53ChangesFunction() function.Function {
+	return &RecordsToRoute53ChangesFunction{}
+}
+
+// RecordsToRoute53ChangesFunction defines the function implementation.
+type RecordsToRoute53ChangesFunction struct{}
+
+// route53ChangeBatch is the change batch of the Route 53
+// ChangeResourceRecordSets API.
+type route53ChangeBatch struct {
+	Changes []route53Change `json:"Changes"`
+}
+
+type route53Change struct {
+	Action            string                   `json:"Action"`
+	ResourceRecordSet route53ResourceRecordSet `json:"ResourceRecordSet"`
+}
+
+type route53ResourceRecordSet struct {
+	Name            string                  `json:"Name"`
+	Type            string                  `json:"Type"`
+	TTL             int64                   `json:"TTL"`
+	ResourceRecords []route53ResourceRecord `json:"ResourceRecords"`
+}
+
+type route53ResourceRecord struct {
+	Value string `json:"Value"`
+}
+
+func (f *RecordsToRoute53ChangesFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "records_to_route53_changes"
+}
+
+func (f *RecordsToRoute53ChangesFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Render DNS records as a Route 53 change batch",
+		MarkdownDescription: "Renders DNS records, such as the ones required to verify a domain, as the JSON change batch of the Route 53 `ChangeResourceRecordSets` API, e.g. for `aws route53 change-resource-record-sets --change-batch`. " +
+			"Every record set is upserted. Records with the same name and type are combined into one record set, TXT values are quoted and split into strings of at most 255 characters and records with an `Auto` TTL get a TTL of 3600 seconds.",
+		Parameters: []function.Parameter{
+			recordsParameter(),
+			zoneParameter("zone"),
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *RecordsToRoute53ChangesFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	ctx = withLogMasking(ctx)
+
+	var records []DnsRecord
+	var zone string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &records, &zone))
+	if resp.Error != nil {
+		return
+	}
+
+	changes, err := recordsToRoute53Changes(records, zone)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, changes))
+}
+
+// recordsToRoute53Changes renders records as a Route 53 change batch.
+func recordsToRoute53Changes(records []DnsRecord, zone string) (string, error) {
+	zone, err := normalizeZone(zone)
+	if err != nil {
+		return "", err
+	}
+
+	batch := route53ChangeBatch{Changes: []route53Change{}}
+	// Route 53 has a single record set per name and type, which holds all of
+	// their values.
+	sets := map[string]int{}
+	for _, record := range records {
+		data, err := recordData(record)
+		if err != nil {
+			return "", err
+		}
+		ttl, ok, err := recordTtl(record)
+		if err != nil {
+			return "", err
+		}
+		if !ok {
+			ttl = defaultRecordTtl
+		}
+
+		name := strings.ToLower(recordFqdn(record.Name.ValueString(), zone)) + "."
+		recordType := strings.ToUpper(record.Type.ValueString())
+
+		key := name + " " + recordType
+		if i, ok := sets[key]; ok {
+			set := &batch.Changes[i].ResourceRecordSet
+			set.ResourceRecords = append(set.ResourceRecords, route53ResourceRecord{Value: data})
+			set.TTL = min(set.TTL, ttl)
+			continue
+		}
+
+		batch.Changes = append(batch.Changes, route53Change{
+			Action: "UPSERT",
+			ResourceRecordSet: route53ResourceRecordSet{
+				Name:            name,
+				Type:            recordType,
+				TTL:             ttl,
+				ResourceRecords: []route53ResourceRecord{{Value: data}},
+			},
+		})
+		sets[key] = len(batch.Changes) - 1
+	}
+
+	b, err := json.Marshal(batch)
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
@@ -0,0 +1,38 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccRecordsToRoute53ChangesFunction(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRecords + `
+output "test" {
+  value = provider::resend::records_to_route53_changes(concat(local.records, [{
+    name     = "send.example.com."
+    type     = "TXT"
+    value    = "google-site-verification=abc"
+    ttl      = null
+    priority = null
+  }]), "example.com")
+}
+`,
+				Check: resource.TestCheckOutput("test", `{"Changes":[`+
+					`{"Action":"UPSERT","ResourceRecordSet":{"Name":"send.example.com.","Type":"MX","TTL":3600,"ResourceRecords":[{"Value":"10 feedback-smtp.us-east-1.amazonses.com."}]}},`+
+					`{"Action":"UPSERT","ResourceRecordSet":{"Name":"send.example.com.","Type":"TXT","TTL":3600,"ResourceRecords":[{"Value":"\"v=spf1 include:amazonses.com ~all\""},{"Value":"\"google-site-verification=abc\""}]}},`+
+					`{"Action":"UPSERT","ResourceRecordSet":{"Name":"resend._domainkey.example.com.","Type":"TXT","TTL":300,"ResourceRecords":[{"Value":"\"p=`+strings.Repeat("A", 253)+`\" \"`+strings.Repeat("A", 47)+`\""}]}}`+
+					`]}`),
+			},
+		},
+	})
+}
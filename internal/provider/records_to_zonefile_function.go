@@ -0,0 +1,91 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &RecordsToZonefileFunction{}
+
+func NewRecordsToZonefileFunction() function.Function {
+	return &RecordsToZonefileFunction{}
+}
+
+// RecordsToZonefileFunction defines the function implementation.
+type RecordsToZonefileFunction struct{}
+
+func (f *RecordsToZonefileFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "records_to_zonefile"
+}
+
+func (f *RecordsToZonefileFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Render DNS records as a BIND zone file",
+		MarkdownDescription: "Renders DNS records, such as the ones required to verify a domain, as an [RFC 1035](https://datatracker.ietf.org/doc/html/rfc1035#section-5) zone file that can be imported by most DNS providers. " +
+			"Names within the origin are written relative to it, TXT values are quoted and split into strings of at most 255 characters and records with an `Auto` TTL use the `$TTL` of the file.",
+		Parameters: []function.Parameter{
+			recordsParameter(),
+			zoneParameter("origin"),
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *RecordsToZonefileFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	ctx = withLogMasking(ctx)
+
+	var records []DnsRecord
+	var origin string
+
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &records, &origin))
+	if resp.Error != nil {
+		return
+	}
+
+	zonefile, err := recordsToZonefile(records, origin)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, zonefile))
+}
+
+// recordsToZonefile renders records as a zone file with the given origin.
+func recordsToZonefile(records []DnsRecord, origin string) (string, error) {
+	origin, err := normalizeZone(origin)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "$ORIGIN %s.\n", origin)
+	fmt.Fprintf(&b, "$TTL %d\n", defaultRecordTtl)
+
+	for _, record := range records {
+		data, err := recordData(record)
+		if err != nil {
+			return "", err
+		}
+		ttl, ok, err := recordTtl(record)
+		if err != nil {
+			return "", err
+		}
+
+		name := recordRelativeName(recordFqdn(record.Name.ValueString(), origin), origin)
+		if ok {
+			fmt.Fprintf(&b, "%s\t%d\tIN\t%s\t%s\n", name, ttl, strings.ToUpper(record.Type.ValueString()), data)
+		} else {
+			fmt.Fprintf(&b, "%s\tIN\t%s\t%s\n", name, strings.ToUpper(record.Type.ValueString()), data)
+		}
+	}
+
+	return b.String(), nil
+}
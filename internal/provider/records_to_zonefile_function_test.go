@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+// testAccRecords are the records Resend returns for a domain, used to test the
+// record functions.
+var testAccRecords = `
+locals {
+  records = [
+    {
+      record   = "SPF"
+      name     = "send"
+      type     = "MX"
+      ttl      = "Auto"
+      status   = "not_started"
+      value    = "feedback-smtp.us-east-1.amazonses.com"
+      priority = 10
+    },
+    {
+      record   = "SPF"
+      name     = "send"
+      type     = "TXT"
+      ttl      = "Auto"
+      status   = "not_started"
+      value    = "\"v=spf1 include:amazonses.com ~all\""
+      priority = null
+    },
+    {
+      record   = "DKIM"
+      name     = "resend._domainkey"
+      type     = "TXT"
+      ttl      = "300"
+      status   = "not_started"
+      value    = "p=` + strings.Repeat("A", 300) + `"
+      priority = null
+    },
+  ]
+}
+`
+
+func TestAccRecordsToZonefileFunction(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRecords + `
+output "test" {
+  value = provider::resend::records_to_zonefile(local.records, "example.com.")
+}
+`,
+				Check: resource.TestCheckOutput("test", `$ORIGIN example.com.
+$TTL 3600
+send	IN	MX	10 feedback-smtp.us-east-1.amazonses.com.
+send	IN	TXT	"v=spf1 include:amazonses.com ~all"
+resend._domainkey	300	IN	TXT	"p=`+strings.Repeat("A", 253)+`" "`+strings.Repeat("A", 47)+`"
+`),
+			},
+			{
+				Config: testAccRecords + `
+output "test" {
+  value = provider::resend::records_to_zonefile(local.records, "")
+}
+`,
+				ExpectError: regexp.MustCompile(`the zone\s+must not be empty`),
+			},
+		},
+	})
+}
@@ -0,0 +1,33 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegexValidator(t *testing.T) {
+	tests := map[string]bool{
+		"^ci-":            true,
+		`\.example\.com$`: true,
+		"":                true,
+		"[":               false,
+		"(":               false,
+		`\p{Foo}`:         false,
+	}
+
+	for value, valid := range tests {
+		req := validator.StringRequest{Path: path.Root("name_regex"), ConfigValue: types.StringValue(value)}
+		resp := &validator.StringResponse{}
+		regexValidator{}.ValidateString(context.Background(), req, resp)
+		require.Equal(t, !valid, resp.Diagnostics.HasError(), value)
+	}
+
+	resp := &validator.StringResponse{}
+	regexValidator{}.ValidateString(context.Background(), validator.StringRequest{ConfigValue: types.StringUnknown()}, resp)
+	require.False(t, resp.Diagnostics.HasError())
+}
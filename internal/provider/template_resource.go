@@ -0,0 +1,617 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/resend/resend-go/v3"
+)
+
+// templateContentHashKey is the key of the private state that holds the hash
+// of the template content as returned by the API after the last apply.
+const templateContentHashKey = "content_hash"
+
+// templateStatusPublished is the status of templates that can be used to send
+// emails.
+const templateStatusPublished = "published"
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &TemplateResource{}
+var _ resource.ResourceWithImportState = &TemplateResource{}
+var _ resource.ResourceWithValidateConfig = &TemplateResource{}
+
+func NewTemplateResource() resource.Resource {
+	return &TemplateResource{}
+}
+
+// TemplateResource defines the resource implementation.
+type TemplateResource struct {
+	client *resend.Client
+}
+
+// TemplateResourceModel describes the resource data model.
+type TemplateResourceModel struct {
+	Id        types.String `tfsdk:"id"`
+	Name      types.String `tfsdk:"name"`
+	Subject   types.String `tfsdk:"subject"`
+	Html      types.String `tfsdk:"html"`
+	Text      types.String `tfsdk:"text"`
+	Variables types.List   `tfsdk:"variables"`
+	Published types.Bool   `tfsdk:"published"`
+	CreatedAt types.String `tfsdk:"created_at"`
+}
+
+// TemplateVariable is a variable of resend_template.
+type TemplateVariable struct {
+	Key           types.String `tfsdk:"key"`
+	Type          types.String `tfsdk:"type"`
+	FallbackValue types.String `tfsdk:"fallback_value"`
+}
+
+// templateVariableAttrTypes are the attribute types of a TemplateVariable.
+var templateVariableAttrTypes = map[string]attr.Type{
+	"key":            types.StringType,
+	"type":           types.StringType,
+	"fallback_value": types.StringType,
+}
+
+// templateVariableRequest is a variable in the body of a template update.
+type templateVariableRequest struct {
+	Key           string      `json:"key"`
+	Type          string      `json:"type"`
+	FallbackValue interface{} `json:"fallback_value,omitempty"`
+}
+
+// updateTemplateRequest is the body of a template update. It is used instead
+// of resend.UpdateTemplateRequest, which drops empty attributes and so can
+// never remove the subject, text or variables of a template.
+type updateTemplateRequest struct {
+	Name      string                    `json:"name"`
+	Subject   string                    `json:"subject"`
+	Html      string                    `json:"html"`
+	Text      string                    `json:"text"`
+	Variables []templateVariableRequest `json:"variables"`
+}
+
+func (r *TemplateResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_template"
+}
+
+func (r *TemplateResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "An email template, e.g. with the HTML of a file that is edited in the repository. " +
+			"Changes made outside of Terraform, e.g. in the dashboard, are detected by comparing the content of the template with the content after the last apply.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The unique identifier of the template within Resend.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the template.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"subject": schema.StringAttribute{
+				MarkdownDescription: "The default subject of emails sent with the template.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"html": schema.StringAttribute{
+				MarkdownDescription: "The HTML of the template, e.g. `file(\"${path.module}/welcome.html\")`. Variables are inserted with `{{{KEY}}}` and must be declared in `variables`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"text": schema.StringAttribute{
+				MarkdownDescription: "The plain text version of the template. Resend generates it from the HTML if not set.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"variables": schema.ListNestedAttribute{
+				MarkdownDescription: "The variables used in the template.",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"key": schema.StringAttribute{
+							MarkdownDescription: "The key of the variable, e.g. `PRODUCT` for `{{{PRODUCT}}}`.",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "The type of the variable. Possible values: `string` | `number`. Defaults to `string`.",
+							Optional:            true,
+							Computed:            true,
+							Default:             stringdefault.StaticString(string(resend.VariableTypeString)),
+							Validators: []validator.String{
+								stringvalidator.OneOf(string(resend.VariableTypeString), string(resend.VariableTypeNumber)),
+							},
+						},
+						"fallback_value": schema.StringAttribute{
+							MarkdownDescription: "The value used if no value is passed when sending an email. Must be a number for variables of type `number`.",
+							Optional:            true,
+						},
+					},
+				},
+			},
+			"published": schema.BoolAttribute{
+				MarkdownDescription: "Publish the template, so that emails can be sent with it. Changes to a published template are published by the same apply. " +
+					"Published templates cannot be turned into drafts again, setting `published` to `false` replaces the template. Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplaceIf(
+						func(ctx context.Context, req planmodifier.BoolRequest, resp *boolplanmodifier.RequiresReplaceIfFuncResponse) {
+							resp.RequiresReplace = req.StateValue.ValueBool() && !req.PlanValue.ValueBool()
+						},
+						"Unpublishing a template requires replacing it.",
+						"Unpublishing a template requires replacing it.",
+					),
+				},
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "The date and time the template was created.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *TemplateResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*resend.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *resend.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *TemplateResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	ctx = withClientLogMasking(ctx, r.client)
+
+	var data TemplateResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Placeholders are only checked against the declared variables when all
+	// of them are known.
+	known := !data.Variables.IsUnknown()
+
+	var variables []TemplateVariable
+	if known {
+		resp.Diagnostics.Append(data.Variables.ElementsAs(ctx, &variables, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	keys := map[string]bool{}
+	for i, v := range variables {
+		if v.Key.IsUnknown() {
+			known = false
+			continue
+		}
+		if keys[v.Key.ValueString()] {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("variables").AtListIndex(i).AtName("key"),
+				"Duplicate Variable",
+				fmt.Sprintf("The variable %s is declared more than once.", v.Key.ValueString()),
+			)
+		}
+		keys[v.Key.ValueString()] = true
+
+		if v.Type.ValueString() != string(resend.VariableTypeNumber) || v.FallbackValue.IsNull() || v.FallbackValue.IsUnknown() {
+			continue
+		}
+		if _, err := strconv.ParseFloat(v.FallbackValue.ValueString(), 64); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("variables").AtListIndex(i).AtName("fallback_value"),
+				"Invalid Fallback Value",
+				fmt.Sprintf("The fallback value of the number variable %s must be a number, got %q.", v.Key.ValueString(), v.FallbackValue.ValueString()),
+			)
+		}
+	}
+
+	content := []struct {
+		name  string
+		value types.String
+	}{{"subject", data.Subject}, {"html", data.Html}, {"text", data.Text}}
+	for _, c := range content {
+		if c.value.IsUnknown() {
+			known = false
+		}
+	}
+
+	used := map[string]bool{}
+	for _, c := range content {
+		for _, p := range placeholdersOf(path.Root(c.name), c.value, &resp.Diagnostics) {
+			used[p.Key] = true
+			if known && !keys[p.Key] {
+				resp.Diagnostics.AddAttributeError(
+					path.Root(c.name),
+					"Undeclared Variable",
+					fmt.Sprintf("Line %d: the variable %s is not declared in variables, so it would be empty in emails sent with the template.", p.Line, p.Key),
+				)
+			}
+		}
+	}
+
+	if !known {
+		return
+	}
+	for i, v := range variables {
+		if !used[v.Key.ValueString()] {
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("variables").AtListIndex(i).AtName("key"),
+				"Unused Variable",
+				fmt.Sprintf("The variable %s is not used in the subject, html or text of the template.", v.Key.ValueString()),
+			)
+		}
+	}
+}
+
+func (r *TemplateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withClientLogMasking(ctx, r.client)
+
+	var data TemplateResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	variables := templateVariablesRequest(ctx, data.Variables, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := &resend.CreateTemplateRequest{
+		Name:    data.Name.ValueString(),
+		Subject: data.Subject.ValueString(),
+		Html:    data.Html.ValueString(),
+		Text:    data.Text.ValueString(),
+	}
+	for _, v := range variables {
+		params.Variables = append(params.Variables, &resend.TemplateVariable{
+			Key:           v.Key,
+			Type:          resend.VariableType(v.Type),
+			FallbackValue: v.FallbackValue,
+		})
+	}
+
+	template, err := r.client.Templates.CreateWithContext(ctx, params)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create template, got error: %s", err))
+		return
+	}
+	data.Id = types.StringValue(template.Id)
+
+	if data.Published.ValueBool() {
+		r.publish(ctx, data, &resp.Diagnostics)
+	}
+
+	data.CreatedAt = types.StringNull()
+	remote := r.readContent(ctx, data, resp.Private, &resp.Diagnostics)
+	if remote != nil {
+		data.CreatedAt = types.StringValue(remote.CreatedAt)
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TemplateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withClientLogMasking(ctx, r.client)
+
+	var data TemplateResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	template, err := r.client.Templates.GetWithContext(ctx, data.Id.ValueString())
+	if isNotFound(err) {
+		tflog.Warn(ctx, "template not found, removing from state", map[string]interface{}{
+			"id": data.Id.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read template, got error: %s", err))
+		return
+	}
+	data.Published = types.BoolValue(template.Status == templateStatusPublished)
+	data.CreatedAt = types.StringValue(template.CreatedAt)
+
+	// The API may return the content differently than it was sent, e.g.
+	// with normalized HTML, so it is only compared with the content after
+	// the last apply. The state keeps the configured content unless the
+	// template has been changed since.
+	hash := templateContentHash(template)
+	stored, diags := req.Private.GetKey(ctx, templateContentHashKey)
+	resp.Diagnostics.Append(diags...)
+
+	var storedHash string
+	if len(stored) > 0 {
+		_ = json.Unmarshal(stored, &storedHash)
+	}
+	if storedHash != hash {
+		tflog.Info(ctx, "template content changed outside of Terraform", map[string]interface{}{
+			"id": data.Id.ValueString(),
+		})
+
+		data.Name = types.StringValue(template.Name)
+		data.Subject = stringOrNull(template.Subject)
+		data.Html = types.StringValue(template.Html)
+		data.Text = stringOrNull(template.Text)
+
+		variables, diags := templateVariablesValue(ctx, template.Variables)
+		resp.Diagnostics.Append(diags...)
+		data.Variables = variables
+
+		resp.Diagnostics.Append(setTemplateContentHash(ctx, resp.Private, hash)...)
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TemplateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withClientLogMasking(ctx, r.client)
+
+	var data TemplateResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	variables := templateVariablesRequest(ctx, data.Variables, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	params := &updateTemplateRequest{
+		Name:      data.Name.ValueString(),
+		Subject:   data.Subject.ValueString(),
+		Html:      data.Html.ValueString(),
+		Text:      data.Text.ValueString(),
+		Variables: variables,
+	}
+
+	httpReq, err := r.client.NewRequest(ctx, http.MethodPatch, "templates/"+data.Id.ValueString(), params)
+	if err == nil {
+		_, err = r.client.Perform(httpReq, nil)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update template, got error: %s", err))
+		return
+	}
+
+	// Publish the changes, as updates only change the draft.
+	if data.Published.ValueBool() {
+		r.publish(ctx, data, &resp.Diagnostics)
+	}
+
+	r.readContent(ctx, data, resp.Private, &resp.Diagnostics)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// publish publishes the template. Errors are added to diags.
+func (r *TemplateResource) publish(ctx context.Context, data TemplateResourceModel, diags *diag.Diagnostics) {
+	_, err := r.client.Templates.PublishWithContext(ctx, data.Id.ValueString())
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to publish template, got error: %s", err))
+	}
+}
+
+// readContent reads the template after it has been written and keeps the hash
+// of its content in the private state, so that Read can tell whether it has
+// been changed since. It returns nil if the template cannot be read, in
+// which case the next Read takes the content from the API.
+func (r *TemplateResource) readContent(ctx context.Context, data TemplateResourceModel, private privateState, diags *diag.Diagnostics) *resend.Template {
+	template, err := r.client.Templates.GetWithContext(ctx, data.Id.ValueString())
+	if err != nil {
+		diags.AddWarning("Client Error", fmt.Sprintf("Unable to read template to detect later changes, got error: %s", err))
+		return nil
+	}
+
+	diags.Append(setTemplateContentHash(ctx, private, templateContentHash(template))...)
+	return template
+}
+
+func (r *TemplateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withClientLogMasking(ctx, r.client)
+
+	var data TemplateResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.Templates.RemoveWithContext(ctx, data.Id.ValueString())
+	// The template has already been deleted outside of Terraform.
+	if isNotFound(err) {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete template, got error: %s", err))
+		return
+	}
+}
+
+func (r *TemplateResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx = withClientLogMasking(ctx, r.client)
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// privateState is the private state of a resource response.
+type privateState interface {
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// setTemplateContentHash keeps the hash of the template content in the
+// private state.
+func setTemplateContentHash(ctx context.Context, private privateState, hash string) diag.Diagnostics {
+	value, err := json.Marshal(hash)
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError("Unable to store content hash", err.Error())
+		return diags
+	}
+
+	return private.SetKey(ctx, templateContentHashKey, value)
+}
+
+// templateContentHash returns a hash of the attributes of a template that
+// are managed by resend_template, other than published.
+func templateContentHash(template *resend.Template) string {
+	variables := make([]templateVariableRequest, len(template.Variables))
+	for i, v := range template.Variables {
+		variables[i] = templateVariableRequest{Key: v.Key, Type: string(v.Type), FallbackValue: fallbackString(v.FallbackValue)}
+	}
+	sort.Slice(variables, func(i, j int) bool { return variables[i].Key < variables[j].Key })
+
+	content, _ := json.Marshal(updateTemplateRequest{
+		Name:      template.Name,
+		Subject:   template.Subject,
+		Html:      template.Html,
+		Text:      template.Text,
+		Variables: variables,
+	})
+	sum := sha256.Sum256(content)
+
+	return hex.EncodeToString(sum[:])
+}
+
+// templateVariablesRequest converts the variables of the model for a create
+// or update request. Fallback values of number variables are sent as
+// numbers, ValidateConfig ensures they are valid.
+func templateVariablesRequest(ctx context.Context, value types.List, diags *diag.Diagnostics) []templateVariableRequest {
+	var variables []TemplateVariable
+	diags.Append(value.ElementsAs(ctx, &variables, false)...)
+
+	requests := []templateVariableRequest{}
+	for _, v := range variables {
+		request := templateVariableRequest{Key: v.Key.ValueString(), Type: v.Type.ValueString()}
+		if !v.FallbackValue.IsNull() {
+			request.FallbackValue = v.FallbackValue.ValueString()
+			if v.Type.ValueString() == string(resend.VariableTypeNumber) {
+				number, err := strconv.ParseFloat(v.FallbackValue.ValueString(), 64)
+				if err != nil {
+					diags.AddError("Invalid Fallback Value", fmt.Sprintf("The fallback value of %s must be a number: %s", v.Key.ValueString(), err))
+					continue
+				}
+				request.FallbackValue = number
+			}
+		}
+		requests = append(requests, request)
+	}
+
+	return requests
+}
+
+// templateVariablesValue converts the variables returned by the API into a
+// list value. Templates without variables have a null list.
+func templateVariablesValue(ctx context.Context, variables []*resend.TemplateVariableResponse) (types.List, diag.Diagnostics) {
+	if len(variables) == 0 {
+		return types.ListNull(types.ObjectType{AttrTypes: templateVariableAttrTypes}), nil
+	}
+
+	data := make([]TemplateVariable, len(variables))
+	for i, v := range variables {
+		data[i] = TemplateVariable{
+			Key:           types.StringValue(v.Key),
+			Type:          types.StringValue(string(v.Type)),
+			FallbackValue: types.StringPointerValue(fallbackString(v.FallbackValue)),
+		}
+	}
+
+	return types.ListValueFrom(ctx, types.ObjectType{AttrTypes: templateVariableAttrTypes}, data)
+}
+
+// fallbackString returns the fallback value of a variable as a string, or nil
+// if it has none. The API returns numbers for number variables.
+func fallbackString(value interface{}) *string {
+	var s string
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case string:
+		s = v
+	case float64:
+		s = strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		s = fmt.Sprint(v)
+	}
+
+	return &s
+}
@@ -0,0 +1,196 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/resend/resend-go/v3"
+)
+
+func TestAccTemplateResource(t *testing.T) {
+	var id string
+
+	config := providerConfig + `
+resource "resend_template" "test" {
+  name    = "welcome"
+  subject = "Welcome to Acme"
+  html    = "<p>Hi {{{NAME}}}, you have {{{CREDITS}}} credits.</p>"
+
+  variables = [
+    {
+      key            = "NAME"
+      fallback_value = "there"
+    },
+    {
+      key            = "CREDITS"
+      type           = "number"
+      fallback_value = "5"
+    },
+  ]
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Fallback values of number variables are validated
+			{
+				Config: providerConfig + `
+resource "resend_template" "test" {
+  name = "welcome"
+  html = "<p>{{{CREDITS}}}</p>"
+
+  variables = [
+    {
+      key            = "CREDITS"
+      type           = "number"
+      fallback_value = "five"
+    },
+  ]
+}
+`,
+				ExpectError: regexp.MustCompile(`fallback value of the number variable CREDITS must be a\s+number`),
+			},
+			// Placeholders are checked against the declared variables
+			{
+				Config: providerConfig + `
+resource "resend_template" "test" {
+  name    = "welcome"
+  subject = "Welcome {{{NAME}}}"
+  html    = "<p>Hi {{{NAME}}}, you have {{{CREDIT}}} credits.</p>"
+
+  variables = [
+    {
+      key = "NAME"
+    },
+  ]
+}
+`,
+				ExpectError: regexp.MustCompile(`the variable CREDIT is not declared in variables`),
+			},
+			{
+				Config: providerConfig + `
+resource "resend_template" "test" {
+  name = "welcome"
+  html = "<p>Hi {{NAME}}</p>"
+
+  variables = [
+    {
+      key = "NAME"
+    },
+  ]
+}
+`,
+				ExpectError: regexp.MustCompile(`\{\{NAME\}\} must use three braces`),
+			},
+			// Create and Read testing
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("resend_template.test", "name", "welcome"),
+					resource.TestCheckResourceAttr("resend_template.test", "subject", "Welcome to Acme"),
+					resource.TestCheckNoResourceAttr("resend_template.test", "text"),
+					resource.TestCheckResourceAttr("resend_template.test", "variables.#", "2"),
+					resource.TestCheckResourceAttr("resend_template.test", "variables.0.type", "string"),
+					resource.TestCheckResourceAttr("resend_template.test", "variables.1.fallback_value", "5"),
+					resource.TestCheckResourceAttr("resend_template.test", "published", "false"),
+					resource.TestCheckResourceAttrSet("resend_template.test", "created_at"),
+					testAccCaptureAttr("resend_template.test", "id", &id),
+				),
+			},
+			// Changes made outside of Terraform are detected and reverted
+			{
+				PreConfig: func() {
+					_, err := testAccClient().Templates.Update(id, &resend.UpdateTemplateRequest{
+						Name: "welcome",
+						Html: "<p>Edited in the dashboard</p>",
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("resend_template.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPtr("resend_template.test", "id", &id),
+					testAccCheckTemplateHtml(&id, "<p>Hi {{{NAME}}}, you have {{{CREDITS}}} credits.</p>"),
+				),
+			},
+			// Update and publish testing
+			{
+				Config: providerConfig + `
+resource "resend_template" "test" {
+  name      = "welcome-v2"
+  html      = "<p>Hi {{{NAME}}}</p>"
+  text      = "Hi {{{NAME}}}"
+  published = true
+
+  variables = [
+    {
+      key = "NAME"
+    },
+  ]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPtr("resend_template.test", "id", &id),
+					resource.TestCheckResourceAttr("resend_template.test", "name", "welcome-v2"),
+					resource.TestCheckNoResourceAttr("resend_template.test", "subject"),
+					resource.TestCheckResourceAttr("resend_template.test", "text", "Hi {{{NAME}}}"),
+					resource.TestCheckResourceAttr("resend_template.test", "variables.#", "1"),
+					resource.TestCheckNoResourceAttr("resend_template.test", "variables.0.fallback_value"),
+					resource.TestCheckResourceAttr("resend_template.test", "published", "true"),
+					testAccCheckTemplateHtml(&id, "<p>Hi {{{NAME}}}</p>"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "resend_template.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Published templates are replaced to turn them into drafts
+			{
+				Config: providerConfig + `
+resource "resend_template" "test" {
+  name = "welcome-v2"
+  html = "<p>Hi there</p>"
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("resend_template.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("resend_template.test", "published", "false"),
+					testAccCheckAttrChanged("resend_template.test", "id", &id),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+// testAccCheckTemplateHtml checks the HTML of the template as returned by the
+// API.
+func testAccCheckTemplateHtml(id *string, html string) func(*terraform.State) error {
+	return func(s *terraform.State) error {
+		template, err := testAccClient().Templates.Get(*id)
+		if err != nil {
+			return err
+		}
+		if template.Html != html {
+			return fmt.Errorf("expected template HTML %q, got %q", html, template.Html)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,311 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/resend/resend-go/v3"
+)
+
+// webhookEvents are the event types a webhook can subscribe to.
+var webhookEvents = []string{
+	resend.EventEmailSent,
+	resend.EventEmailDelivered,
+	resend.EventEmailDeliveryDelayed,
+	resend.EventEmailComplained,
+	resend.EventEmailBounced,
+	resend.EventEmailOpened,
+	resend.EventEmailClicked,
+	resend.EventEmailReceived,
+	resend.EventEmailFailed,
+	resend.EventContactCreated,
+	resend.EventContactUpdated,
+	resend.EventContactDeleted,
+	resend.EventDomainCreated,
+	resend.EventDomainUpdated,
+	resend.EventDomainDeleted,
+}
+
+const (
+	webhookStatusEnabled  = "enabled"
+	webhookStatusDisabled = "disabled"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &WebhookResource{}
+var _ resource.ResourceWithImportState = &WebhookResource{}
+
+func NewWebhookResource() resource.Resource {
+	return &WebhookResource{}
+}
+
+// WebhookResource defines the resource implementation.
+type WebhookResource struct {
+	client *resend.Client
+}
+
+// WebhookResourceModel describes the resource data model.
+type WebhookResourceModel struct {
+	Id            types.String `tfsdk:"id"`
+	Endpoint      types.String `tfsdk:"endpoint"`
+	Events        types.Set    `tfsdk:"events"`
+	Enabled       types.Bool   `tfsdk:"enabled"`
+	SigningSecret types.String `tfsdk:"signing_secret"`
+	CreatedAt     types.String `tfsdk:"created_at"`
+}
+
+func (r *WebhookResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_webhook"
+}
+
+func (r *WebhookResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "A webhook that sends events, e.g. bounced emails, to an endpoint.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The unique identifier of the webhook within Resend.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"endpoint": schema.StringAttribute{
+				MarkdownDescription: "The URL the events are sent to.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^https?://[^/]+`), "must be an http or https URL"),
+				},
+			},
+			"events": schema.SetAttribute{
+				MarkdownDescription: "The event types sent to the endpoint. Possible values: `" + strings.Join(webhookEvents, "` | `") + "`",
+				ElementType:         types.StringType,
+				Required:            true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.OneOf(webhookEvents...)),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether events are sent to the endpoint. Defaults to `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"signing_secret": schema.StringAttribute{
+				MarkdownDescription: "The secret used to verify that events were sent by Resend, e.g. with the `Verify` function of the Resend SDKs.",
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "The date and time the webhook was created.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *WebhookResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*resend.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *resend.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *WebhookResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx = withClientLogMasking(ctx, r.client)
+
+	var data WebhookResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var events []string
+	resp.Diagnostics.Append(data.Events.ElementsAs(ctx, &events, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	webhook, err := r.client.Webhooks.CreateWithContext(ctx, &resend.CreateWebhookRequest{
+		Endpoint: data.Endpoint.ValueString(),
+		Events:   events,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create webhook, got error: %s", err))
+		return
+	}
+	data.Id = types.StringValue(webhook.Id)
+	data.SigningSecret = types.StringValue(webhook.SigningSecret)
+	data.CreatedAt = types.StringNull()
+
+	// New webhooks are enabled, they can only be disabled once they exist.
+	if !data.Enabled.ValueBool() {
+		status := webhookStatusDisabled
+		_, err = r.client.Webhooks.UpdateWithContext(ctx, webhook.Id, &resend.UpdateWebhookRequest{Status: &status})
+		if err != nil {
+			// The webhook is still saved, Terraform marks it as tainted.
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to disable webhook, got error: %s", err))
+		}
+	}
+
+	// The creation time is not part of the create response.
+	created, err := r.client.Webhooks.GetWithContext(ctx, webhook.Id)
+	if err != nil {
+		resp.Diagnostics.AddWarning("Client Error", fmt.Sprintf("Unable to read webhook to read the creation time, got error: %s", err))
+	} else {
+		data.CreatedAt = types.StringValue(created.CreatedAt)
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *WebhookResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx = withClientLogMasking(ctx, r.client)
+
+	var data WebhookResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	webhook, err := r.client.Webhooks.GetWithContext(ctx, data.Id.ValueString())
+	if isNotFound(err) {
+		tflog.Warn(ctx, "webhook not found, removing from state", map[string]interface{}{
+			"id": data.Id.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read webhook, got error: %s", err))
+		return
+	}
+
+	events, diags := types.SetValueFrom(ctx, types.StringType, webhook.Events)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.Endpoint = types.StringValue(webhook.Endpoint)
+	data.Events = events
+	data.Enabled = types.BoolValue(webhook.Status != webhookStatusDisabled)
+	data.CreatedAt = types.StringValue(webhook.CreatedAt)
+	// Keep the secret of the state if the API does not return it.
+	if webhook.SigningSecret != "" {
+		data.SigningSecret = types.StringValue(webhook.SigningSecret)
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *WebhookResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx = withClientLogMasking(ctx, r.client)
+
+	var data WebhookResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var events []string
+	resp.Diagnostics.Append(data.Events.ElementsAs(ctx, &events, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	endpoint := data.Endpoint.ValueString()
+	status := webhookStatusEnabled
+	if !data.Enabled.ValueBool() {
+		status = webhookStatusDisabled
+	}
+
+	_, err := r.client.Webhooks.UpdateWithContext(ctx, data.Id.ValueString(), &resend.UpdateWebhookRequest{
+		Endpoint: &endpoint,
+		Events:   events,
+		Status:   &status,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update webhook, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *WebhookResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx = withClientLogMasking(ctx, r.client)
+
+	var data WebhookResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.Webhooks.RemoveWithContext(ctx, data.Id.ValueString())
+	// The webhook has already been deleted outside of Terraform.
+	if isNotFound(err) {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete webhook, got error: %s", err))
+		return
+	}
+}
+
+func (r *WebhookResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ctx = withClientLogMasking(ctx, r.client)
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccWebhookResource(t *testing.T) {
+	var id, secret string
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Events are validated before the webhook is created
+			{
+				Config: providerConfig + `
+resource "resend_webhook" "test" {
+  endpoint = "https://example.com/webhooks/resend"
+  events   = ["email.bounced", "email.unknown"]
+}
+`,
+				ExpectError: regexp.MustCompile(`value must be one of`),
+			},
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "resend_webhook" "test" {
+  endpoint = "https://example.com/webhooks/resend"
+  events   = ["email.bounced", "email.complained"]
+}
+
+# The secret can be passed to the service that receives the events.
+output "signing_secret" {
+  value     = resend_webhook.test.signing_secret
+  sensitive = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("resend_webhook.test", "endpoint", "https://example.com/webhooks/resend"),
+					resource.TestCheckTypeSetElemAttr("resend_webhook.test", "events.*", "email.bounced"),
+					resource.TestCheckTypeSetElemAttr("resend_webhook.test", "events.*", "email.complained"),
+					resource.TestCheckResourceAttr("resend_webhook.test", "enabled", "true"),
+					resource.TestMatchResourceAttr("resend_webhook.test", "signing_secret", regexp.MustCompile(`^whsec_`)),
+					resource.TestCheckResourceAttrSet("resend_webhook.test", "created_at"),
+					testAccCaptureAttr("resend_webhook.test", "id", &id),
+					testAccCaptureAttr("resend_webhook.test", "signing_secret", &secret),
+				),
+			},
+			// Update testing, the webhook and its secret are kept
+			{
+				Config: providerConfig + `
+resource "resend_webhook" "test" {
+  endpoint = "https://example.com/webhooks/resend/v2"
+  events   = ["email.delivered"]
+  enabled  = false
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPtr("resend_webhook.test", "id", &id),
+					resource.TestCheckResourceAttrPtr("resend_webhook.test", "signing_secret", &secret),
+					resource.TestCheckResourceAttr("resend_webhook.test", "endpoint", "https://example.com/webhooks/resend/v2"),
+					resource.TestCheckResourceAttr("resend_webhook.test", "events.#", "1"),
+					resource.TestCheckResourceAttr("resend_webhook.test", "enabled", "false"),
+					func(s *terraform.State) error {
+						webhook, err := testAccClient().Webhooks.Get(id)
+						if err != nil {
+							return err
+						}
+						if webhook.Status != "disabled" {
+							return fmt.Errorf("expected webhook to be disabled, got %s", webhook.Status)
+						}
+						return nil
+					},
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "resend_webhook.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccWebhookResourceDisabled(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "resend_webhook" "test" {
+  endpoint = "https://example.com/webhooks/resend"
+  events   = ["email.bounced"]
+  enabled  = false
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("resend_webhook.test", "enabled", "false"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,119 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resendfake
+
+import (
+	"net/http"
+	"strings"
+)
+
+// ApiKey is an API key stored in the fake.
+type ApiKey struct {
+	sequence int
+
+	Id         string `json:"id"`
+	Name       string `json:"name"`
+	CreatedAt  string `json:"created_at"`
+	Permission string `json:"-"`
+	DomainId   string `json:"-"`
+}
+
+// ApiKey returns a copy of the API key with the given ID.
+func (s *Server) ApiKey(id string) (ApiKey, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	k, ok := s.apiKeys[id]
+	if !ok {
+		return ApiKey{}, false
+	}
+
+	return *k, true
+}
+
+func (s *Server) createApiKey(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Name       string `json:"name"`
+		Permission string `json:"permission"`
+		DomainId   string `json:"domain_id"`
+	}
+	if !decode(w, r, &params) {
+		return
+	}
+
+	if params.Name == "" {
+		writeError(w, http.StatusUnprocessableEntity, "missing_required_field", "Missing `name` field.")
+		return
+	}
+	switch params.Permission {
+	case "":
+		params.Permission = "full_access"
+	case "full_access", "sending_access":
+	default:
+		writeError(w, http.StatusUnprocessableEntity, "validation_error", "Invalid `permission` field.")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if params.DomainId != "" {
+		if params.Permission != "sending_access" {
+			writeError(w, http.StatusUnprocessableEntity, "validation_error", "The `domain_id` field requires the `sending_access` permission.")
+			return
+		}
+		if _, ok := s.domains[params.DomainId]; !ok {
+			writeNotFound(w, "Domain")
+			return
+		}
+	}
+
+	k := &ApiKey{
+		sequence:   s.nextSequence(),
+		Id:         s.newId(),
+		Name:       params.Name,
+		CreatedAt:  now(),
+		Permission: params.Permission,
+		DomainId:   params.DomainId,
+	}
+	s.apiKeys[k.Id] = k
+
+	writeJSON(w, http.StatusCreated, map[string]string{
+		"id":    k.Id,
+		"token": "re_" + strings.ReplaceAll(s.newId(), "-", ""),
+	})
+}
+
+func (s *Server) listApiKeys(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids, hasMore, err := page(r, sortedIds(s.apiKeys, func(k *ApiKey) int { return k.sequence }))
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, "validation_error", err.Error())
+		return
+	}
+
+	resp := list[ApiKey]{Object: "list", Data: []ApiKey{}, HasMore: hasMore}
+	for _, id := range ids {
+		resp.Data = append(resp.Data, *s.apiKeys[id])
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) removeApiKey(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	if _, ok := s.apiKeys[id]; !ok {
+		writeNotFound(w, "API key")
+		return
+	}
+	delete(s.apiKeys, id)
+
+	// The real API responds with an empty body.
+	w.WriteHeader(http.StatusOK)
+}
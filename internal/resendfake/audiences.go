@@ -0,0 +1,113 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resendfake
+
+import (
+	"net/http"
+)
+
+// Audience is an audience stored in the fake. Resend has renamed audiences to
+// segments, both APIs work on the same objects.
+type Audience struct {
+	sequence int
+
+	Id        string `json:"id"`
+	Object    string `json:"object"`
+	Name      string `json:"name"`
+	CreatedAt string `json:"created_at"`
+}
+
+// Audience returns a copy of the audience with the given ID.
+func (s *Server) Audience(id string) (Audience, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a, ok := s.audiences[id]
+	if !ok {
+		return Audience{}, false
+	}
+
+	return *a, true
+}
+
+func (s *Server) createAudience(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Name string `json:"name"`
+	}
+	if !decode(w, r, &params) {
+		return
+	}
+
+	if params.Name == "" {
+		writeError(w, http.StatusUnprocessableEntity, "missing_required_field", "Missing `name` field.")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a := &Audience{
+		sequence:  s.nextSequence(),
+		Id:        s.newId(),
+		Object:    "segment",
+		Name:      params.Name,
+		CreatedAt: now(),
+	}
+	s.audiences[a.Id] = a
+
+	writeJSON(w, http.StatusCreated, map[string]string{
+		"object": a.Object,
+		"id":     a.Id,
+		"name":   a.Name,
+	})
+}
+
+func (s *Server) listAudiences(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids, hasMore, err := page(r, sortedIds(s.audiences, func(a *Audience) int { return a.sequence }))
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, "validation_error", err.Error())
+		return
+	}
+
+	resp := list[Audience]{Object: "list", Data: []Audience{}, HasMore: hasMore}
+	for _, id := range ids {
+		resp.Data = append(resp.Data, *s.audiences[id])
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) getAudience(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a, ok := s.audiences[r.PathValue("id")]
+	if !ok {
+		writeNotFound(w, "Segment")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, a)
+}
+
+func (s *Server) removeAudience(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	if _, ok := s.audiences[id]; !ok {
+		writeNotFound(w, "Segment")
+		return
+	}
+	delete(s.audiences, id)
+	// The contacts of an audience are deleted with it.
+	for contactId := range s.audienceContacts(id) {
+		delete(s.contacts, contactId)
+	}
+
+	writeJSON(w, http.StatusOK, deleted{Object: "segment", Id: id, Deleted: true})
+}
@@ -0,0 +1,262 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resendfake
+
+import (
+	"net/http"
+)
+
+const (
+	broadcastStatusDraft     = "draft"
+	broadcastStatusScheduled = "scheduled"
+	broadcastStatusQueued    = "queued"
+	broadcastStatusSent      = "sent"
+)
+
+// Broadcast is a broadcast stored in the fake.
+type Broadcast struct {
+	sequence int
+
+	Id          string   `json:"id"`
+	Object      string   `json:"object"`
+	Name        string   `json:"name"`
+	SegmentId   string   `json:"segment_id"`
+	AudienceId  string   `json:"audience_id"`
+	From        string   `json:"from"`
+	Subject     string   `json:"subject"`
+	ReplyTo     []string `json:"reply_to"`
+	Html        string   `json:"html"`
+	Text        string   `json:"text"`
+	Status      string   `json:"status"`
+	CreatedAt   string   `json:"created_at"`
+	ScheduledAt *string  `json:"scheduled_at"`
+	SentAt      *string  `json:"sent_at"`
+}
+
+// Broadcast returns a copy of the broadcast with the given ID.
+func (s *Server) Broadcast(id string) (Broadcast, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, ok := s.broadcasts[id]
+	if !ok {
+		return Broadcast{}, false
+	}
+
+	return *b, true
+}
+
+// broadcastParams are the attributes of a broadcast that can be created and
+// updated. Attributes that are not sent are left unchanged by an update.
+type broadcastParams struct {
+	SegmentId  *string   `json:"segment_id"`
+	AudienceId *string   `json:"audience_id"`
+	From       *string   `json:"from"`
+	Subject    *string   `json:"subject"`
+	ReplyTo    *[]string `json:"reply_to"`
+	Html       *string   `json:"html"`
+	Text       *string   `json:"text"`
+	Name       *string   `json:"name"`
+}
+
+// applyBroadcastParams sets the attributes of b that are set in p. It returns false and
+// writes an error if the segment does not exist. s.mu must be held.
+func (s *Server) applyBroadcastParams(w http.ResponseWriter, b *Broadcast, p broadcastParams) bool {
+	// The audience ID is the deprecated name of the segment ID.
+	segmentId := p.SegmentId
+	if segmentId == nil {
+		segmentId = p.AudienceId
+	}
+	if segmentId != nil {
+		if _, ok := s.audiences[*segmentId]; !ok {
+			writeNotFound(w, "Segment")
+			return false
+		}
+		b.SegmentId = *segmentId
+		b.AudienceId = *segmentId
+	}
+
+	set := func(field *string, value *string) {
+		if value != nil {
+			*field = *value
+		}
+	}
+	set(&b.From, p.From)
+	set(&b.Subject, p.Subject)
+	set(&b.Html, p.Html)
+	set(&b.Text, p.Text)
+	set(&b.Name, p.Name)
+	if p.ReplyTo != nil {
+		b.ReplyTo = *p.ReplyTo
+	}
+
+	return true
+}
+
+func (s *Server) createBroadcast(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		broadcastParams
+		Send        bool   `json:"send"`
+		ScheduledAt string `json:"scheduled_at"`
+	}
+	if !decode(w, r, &params) {
+		return
+	}
+
+	if params.SegmentId == nil && params.AudienceId == nil {
+		writeError(w, http.StatusUnprocessableEntity, "missing_required_field", "Missing `segment_id` field.")
+		return
+	}
+	if params.From == nil || *params.From == "" {
+		writeError(w, http.StatusUnprocessableEntity, "missing_required_field", "Missing `from` field.")
+		return
+	}
+	if params.Subject == nil || *params.Subject == "" {
+		writeError(w, http.StatusUnprocessableEntity, "missing_required_field", "Missing `subject` field.")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b := &Broadcast{
+		sequence:  s.nextSequence(),
+		Id:        s.newId(),
+		Object:    "broadcast",
+		ReplyTo:   []string{},
+		Status:    broadcastStatusDraft,
+		CreatedAt: now(),
+	}
+	if !s.applyBroadcastParams(w, b, params.broadcastParams) {
+		return
+	}
+	if params.Send {
+		b.send(params.ScheduledAt)
+	}
+	s.broadcasts[b.Id] = b
+
+	writeJSON(w, http.StatusCreated, map[string]string{"id": b.Id})
+}
+
+func (s *Server) listBroadcasts(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids, hasMore, err := page(r, sortedIds(s.broadcasts, func(b *Broadcast) int { return b.sequence }))
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, "validation_error", err.Error())
+		return
+	}
+
+	resp := list[Broadcast]{Object: "list", Data: []Broadcast{}, HasMore: hasMore}
+	for _, id := range ids {
+		resp.Data = append(resp.Data, *s.broadcasts[id])
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) getBroadcast(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, ok := s.broadcasts[r.PathValue("id")]
+	if !ok {
+		writeNotFound(w, "Broadcast")
+		return
+	}
+
+	// Queued broadcasts are sent by the first read after they were queued.
+	if b.Status == broadcastStatusQueued {
+		sentAt := now()
+		b.Status = broadcastStatusSent
+		b.SentAt = &sentAt
+	}
+
+	writeJSON(w, http.StatusOK, b)
+}
+
+func (s *Server) updateBroadcast(w http.ResponseWriter, r *http.Request) {
+	var params broadcastParams
+	if !decode(w, r, &params) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, ok := s.broadcasts[r.PathValue("id")]
+	if !ok {
+		writeNotFound(w, "Broadcast")
+		return
+	}
+	if b.Status != broadcastStatusDraft {
+		writeError(w, http.StatusUnprocessableEntity, "validation_error", "Only broadcasts in draft status can be updated.")
+		return
+	}
+
+	if !s.applyBroadcastParams(w, b, params) {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{"id": b.Id})
+}
+
+func (s *Server) sendBroadcast(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		ScheduledAt string `json:"scheduled_at"`
+	}
+	if !decode(w, r, &params) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, ok := s.broadcasts[r.PathValue("id")]
+	if !ok {
+		writeNotFound(w, "Broadcast")
+		return
+	}
+	if b.Status != broadcastStatusDraft {
+		writeError(w, http.StatusUnprocessableEntity, "validation_error", "Only broadcasts in draft status can be sent.")
+		return
+	}
+	b.send(params.ScheduledAt)
+
+	writeJSON(w, http.StatusOK, map[string]string{"id": b.Id})
+}
+
+// send queues the broadcast, or schedules it if scheduledAt is set. The fake
+// does not parse the time, which can also be natural language like
+// "in 1 hour".
+func (b *Broadcast) send(scheduledAt string) {
+	if scheduledAt == "" {
+		b.Status = broadcastStatusQueued
+		return
+	}
+
+	b.Status = broadcastStatusScheduled
+	b.ScheduledAt = &scheduledAt
+}
+
+func (s *Server) removeBroadcast(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	b, ok := s.broadcasts[id]
+	if !ok {
+		writeNotFound(w, "Broadcast")
+		return
+	}
+	// Deleting a scheduled broadcast cancels it.
+	if b.Status != broadcastStatusDraft && b.Status != broadcastStatusScheduled {
+		writeError(w, http.StatusUnprocessableEntity, "validation_error", "Only broadcasts in draft or scheduled status can be deleted.")
+		return
+	}
+	delete(s.broadcasts, id)
+
+	writeJSON(w, http.StatusOK, deleted{Object: "broadcast", Id: id, Deleted: true})
+}
@@ -0,0 +1,195 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resendfake
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Contact is a contact of an audience stored in the fake.
+type Contact struct {
+	sequence   int
+	audienceId string
+
+	Id           string `json:"id"`
+	Object       string `json:"object"`
+	Email        string `json:"email"`
+	FirstName    string `json:"first_name"`
+	LastName     string `json:"last_name"`
+	CreatedAt    string `json:"created_at"`
+	Unsubscribed bool   `json:"unsubscribed"`
+}
+
+// Contact returns a copy of the contact of an audience with the given ID or
+// email.
+func (s *Server) Contact(audienceId, idOrEmail string) (Contact, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.findContact(audienceId, idOrEmail)
+	if c == nil {
+		return Contact{}, false
+	}
+
+	return *c, true
+}
+
+// findContact returns the contact of an audience with the given ID or email,
+// or nil. Emails are compared case-insensitively. s.mu must be held.
+func (s *Server) findContact(audienceId, idOrEmail string) *Contact {
+	for _, c := range s.contacts {
+		if c.audienceId != audienceId {
+			continue
+		}
+		if c.Id == idOrEmail || strings.EqualFold(c.Email, idOrEmail) {
+			return c
+		}
+	}
+
+	return nil
+}
+
+// audienceContacts returns the contacts of an audience by ID. s.mu must be
+// held.
+func (s *Server) audienceContacts(audienceId string) map[string]*Contact {
+	contacts := map[string]*Contact{}
+	for id, c := range s.contacts {
+		if c.audienceId == audienceId {
+			contacts[id] = c
+		}
+	}
+
+	return contacts
+}
+
+func (s *Server) createContact(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Email        string `json:"email"`
+		FirstName    string `json:"first_name"`
+		LastName     string `json:"last_name"`
+		Unsubscribed bool   `json:"unsubscribed"`
+	}
+	if !decode(w, r, &params) {
+		return
+	}
+
+	if params.Email == "" {
+		writeError(w, http.StatusUnprocessableEntity, "missing_required_field", "Missing `email` field.")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	audienceId := r.PathValue("audience_id")
+	if _, ok := s.audiences[audienceId]; !ok {
+		writeNotFound(w, "Audience")
+		return
+	}
+	if s.findContact(audienceId, params.Email) != nil {
+		writeError(w, http.StatusUnprocessableEntity, "validation_error", "Contact "+params.Email+" already exists in the audience.")
+		return
+	}
+
+	c := &Contact{
+		sequence:     s.nextSequence(),
+		audienceId:   audienceId,
+		Id:           s.newId(),
+		Object:       "contact",
+		Email:        params.Email,
+		FirstName:    params.FirstName,
+		LastName:     params.LastName,
+		CreatedAt:    now(),
+		Unsubscribed: params.Unsubscribed,
+	}
+	s.contacts[c.Id] = c
+
+	writeJSON(w, http.StatusCreated, reference{Object: c.Object, Id: c.Id})
+}
+
+func (s *Server) listContacts(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	audienceId := r.PathValue("audience_id")
+	if _, ok := s.audiences[audienceId]; !ok {
+		writeNotFound(w, "Audience")
+		return
+	}
+
+	contacts := s.audienceContacts(audienceId)
+	ids, hasMore, err := page(r, sortedIds(contacts, func(c *Contact) int { return c.sequence }))
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, "validation_error", err.Error())
+		return
+	}
+
+	resp := list[Contact]{Object: "list", Data: []Contact{}, HasMore: hasMore}
+	for _, id := range ids {
+		resp.Data = append(resp.Data, *contacts[id])
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) getContact(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.findContact(r.PathValue("audience_id"), r.PathValue("id"))
+	if c == nil {
+		writeNotFound(w, "Contact")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, c)
+}
+
+func (s *Server) updateContact(w http.ResponseWriter, r *http.Request) {
+	// Fields that are not sent are left unchanged.
+	var params struct {
+		FirstName    *string `json:"first_name"`
+		LastName     *string `json:"last_name"`
+		Unsubscribed *bool   `json:"unsubscribed"`
+	}
+	if !decode(w, r, &params) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.findContact(r.PathValue("audience_id"), r.PathValue("id"))
+	if c == nil {
+		writeNotFound(w, "Contact")
+		return
+	}
+
+	if params.FirstName != nil {
+		c.FirstName = *params.FirstName
+	}
+	if params.LastName != nil {
+		c.LastName = *params.LastName
+	}
+	if params.Unsubscribed != nil {
+		c.Unsubscribed = *params.Unsubscribed
+	}
+
+	writeJSON(w, http.StatusOK, reference{Object: c.Object, Id: c.Id})
+}
+
+func (s *Server) removeContact(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.findContact(r.PathValue("audience_id"), r.PathValue("id"))
+	if c == nil {
+		writeNotFound(w, "Contact")
+		return
+	}
+	delete(s.contacts, c.Id)
+
+	writeJSON(w, http.StatusOK, deleted{Object: c.Object, Id: c.Id, Deleted: true})
+}
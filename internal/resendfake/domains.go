@@ -0,0 +1,273 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resendfake
+
+import (
+	"net/http"
+	"strings"
+)
+
+const (
+	domainStatusNotStarted = "not_started"
+	domainStatusPending    = "pending"
+	domainStatusVerified   = "verified"
+)
+
+// Domain is a domain stored in the fake.
+type Domain struct {
+	sequence int
+
+	Id            string   `json:"id"`
+	Object        string   `json:"object"`
+	Name          string   `json:"name"`
+	CreatedAt     string   `json:"created_at"`
+	Status        string   `json:"status"`
+	Region        string   `json:"region"`
+	Records       []Record `json:"records"`
+	OpenTracking  bool     `json:"open_tracking"`
+	ClickTracking bool     `json:"click_tracking"`
+	Tls           string   `json:"tls"`
+}
+
+// Record is a DNS record of a domain.
+type Record struct {
+	Record   string `json:"record"`
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Ttl      string `json:"ttl"`
+	Status   string `json:"status"`
+	Value    string `json:"value"`
+	Priority *int   `json:"priority,omitempty"`
+}
+
+// setStatus changes the status of the domain and all of its records.
+func (d *Domain) setStatus(status string) {
+	d.Status = status
+	for i := range d.Records {
+		d.Records[i].Status = status
+	}
+}
+
+// Domain returns a copy of the domain with the given ID, e.g. to check its
+// settings in a test.
+func (s *Server) Domain(id string) (Domain, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d, ok := s.domains[id]
+	if !ok {
+		return Domain{}, false
+	}
+
+	return *d, true
+}
+
+// SetDomainStatus changes the status of the domain with the given ID and its
+// records, e.g. to simulate a verification that failed.
+func (s *Server) SetDomainStatus(id, status string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d, ok := s.domains[id]
+	if !ok {
+		return false
+	}
+	d.setStatus(status)
+
+	return true
+}
+
+func (s *Server) createDomain(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Name   string `json:"name"`
+		Region string `json:"region"`
+	}
+	if !decode(w, r, &params) {
+		return
+	}
+
+	if params.Name == "" {
+		writeError(w, http.StatusUnprocessableEntity, "missing_required_field", "Missing `name` field.")
+		return
+	}
+	switch params.Region {
+	case "":
+		params.Region = "us-east-1"
+	case "us-east-1", "eu-west-1", "sa-east-1", "ap-northeast-1":
+	default:
+		writeError(w, http.StatusUnprocessableEntity, "validation_error", "Invalid `region` field.")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, d := range s.domains {
+		if strings.EqualFold(d.Name, params.Name) {
+			writeError(w, http.StatusForbidden, "validation_error", "The "+params.Name+" domain has been registered already.")
+			return
+		}
+	}
+
+	id := s.newId()
+	priority := 10
+	d := &Domain{
+		sequence:  s.nextSequence(),
+		Id:        id,
+		Object:    "domain",
+		Name:      params.Name,
+		CreatedAt: now(),
+		Status:    domainStatusNotStarted,
+		Region:    params.Region,
+		Tls:       "opportunistic",
+		Records: []Record{
+			{
+				Record:   "SPF",
+				Name:     "send",
+				Type:     "MX",
+				Ttl:      "Auto",
+				Status:   domainStatusNotStarted,
+				Value:    "feedback-smtp." + params.Region + ".amazonses.com",
+				Priority: &priority,
+			},
+			{
+				Record: "SPF",
+				Name:   "send",
+				Type:   "TXT",
+				Ttl:    "Auto",
+				Status: domainStatusNotStarted,
+				Value:  "\"v=spf1 include:amazonses.com ~all\"",
+			},
+			{
+				Record: "DKIM",
+				Name:   "resend._domainkey",
+				Type:   "TXT",
+				Ttl:    "Auto",
+				Status: domainStatusNotStarted,
+				Value:  "p=MIGfMA0GCSqGSIb3DQEBAQUAA4GNADCBiQKBgQ" + strings.ReplaceAll(id, "-", ""),
+			},
+		},
+	}
+	s.domains[d.Id] = d
+
+	// The create endpoint uses camel case for some fields.
+	writeJSON(w, http.StatusCreated, map[string]interface{}{
+		"id":          d.Id,
+		"name":        d.Name,
+		"created_at":  d.CreatedAt,
+		"status":      d.Status,
+		"records":     d.Records,
+		"region":      d.Region,
+		"dnsProvider": "Unidentified",
+	})
+}
+
+func (s *Server) listDomains(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids, hasMore, err := page(r, sortedIds(s.domains, func(d *Domain) int { return d.sequence }))
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, "validation_error", err.Error())
+		return
+	}
+
+	resp := list[Domain]{Object: "list", Data: []Domain{}, HasMore: hasMore}
+	for _, id := range ids {
+		// The list endpoint does not include the records.
+		d := *s.domains[id]
+		d.Records = nil
+		resp.Data = append(resp.Data, d)
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) getDomain(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d, ok := s.domains[r.PathValue("id")]
+	if !ok {
+		writeNotFound(w, "Domain")
+		return
+	}
+
+	// Verification finishes on the first read after it has been started.
+	if d.Status == domainStatusPending {
+		d.setStatus(domainStatusVerified)
+	}
+
+	writeJSON(w, http.StatusOK, d)
+}
+
+func (s *Server) updateDomain(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		OpenTracking  *bool  `json:"open_tracking"`
+		ClickTracking *bool  `json:"click_tracking"`
+		Tls           string `json:"tls"`
+	}
+	if !decode(w, r, &params) {
+		return
+	}
+
+	switch params.Tls {
+	case "", "opportunistic", "enforced":
+	default:
+		writeError(w, http.StatusUnprocessableEntity, "validation_error", "Invalid `tls` field.")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d, ok := s.domains[r.PathValue("id")]
+	if !ok {
+		writeNotFound(w, "Domain")
+		return
+	}
+
+	if params.OpenTracking != nil {
+		d.OpenTracking = *params.OpenTracking
+	}
+	if params.ClickTracking != nil {
+		d.ClickTracking = *params.ClickTracking
+	}
+	if params.Tls != "" {
+		d.Tls = params.Tls
+	}
+
+	writeJSON(w, http.StatusOK, reference{Object: "domain", Id: d.Id})
+}
+
+func (s *Server) verifyDomain(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d, ok := s.domains[r.PathValue("id")]
+	if !ok {
+		writeNotFound(w, "Domain")
+		return
+	}
+
+	if d.Status != domainStatusVerified {
+		d.setStatus(domainStatusPending)
+	}
+
+	writeJSON(w, http.StatusOK, reference{Object: "domain", Id: d.Id})
+}
+
+func (s *Server) removeDomain(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	if _, ok := s.domains[id]; !ok {
+		writeNotFound(w, "Domain")
+		return
+	}
+	delete(s.domains, id)
+
+	writeJSON(w, http.StatusOK, deleted{Object: "domain", Id: id, Deleted: true})
+}
@@ -0,0 +1,324 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package resendfake provides an in-memory fake of the Resend API for
+// hermetic acceptance tests.
+//
+// The fake implements the endpoints used by the provider with the request
+// and response formats of the real API. State is kept in memory for the
+// lifetime of the server and errors can be injected for any endpoint.
+package resendfake
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Server is a fake of the Resend API listening on a local address.
+type Server struct {
+	// URL is the base URL of the fake, e.g. http://127.0.0.1:50000/.
+	URL string
+
+	server *httptest.Server
+
+	mu       sync.Mutex
+	sequence int
+	errors   []*injectedError
+	requests map[string]int
+
+	domains    map[string]*Domain
+	apiKeys    map[string]*ApiKey
+	audiences  map[string]*Audience
+	contacts   map[string]*Contact
+	broadcasts map[string]*Broadcast
+	webhooks   map[string]*Webhook
+	templates  map[string]*Template
+}
+
+// injectedError is returned instead of handling the next Count requests that
+// match Method and Path.
+type injectedError struct {
+	Method  string
+	Path    string
+	Status  int
+	Message string
+	Count   int
+}
+
+// NewServer starts a new fake. It must be closed with Close.
+func NewServer() *Server {
+	s := &Server{
+		requests:   map[string]int{},
+		domains:    map[string]*Domain{},
+		apiKeys:    map[string]*ApiKey{},
+		audiences:  map[string]*Audience{},
+		contacts:   map[string]*Contact{},
+		broadcasts: map[string]*Broadcast{},
+		webhooks:   map[string]*Webhook{},
+		templates:  map[string]*Template{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /domains", s.createDomain)
+	mux.HandleFunc("GET /domains", s.listDomains)
+	mux.HandleFunc("GET /domains/{id}", s.getDomain)
+	mux.HandleFunc("PATCH /domains/{id}", s.updateDomain)
+	mux.HandleFunc("DELETE /domains/{id}", s.removeDomain)
+	mux.HandleFunc("POST /domains/{id}/verify", s.verifyDomain)
+
+	mux.HandleFunc("POST /api-keys", s.createApiKey)
+	mux.HandleFunc("GET /api-keys", s.listApiKeys)
+	mux.HandleFunc("DELETE /api-keys/{id}", s.removeApiKey)
+
+	// Audiences have been renamed to segments, the old paths still work.
+	for _, prefix := range []string{"/segments", "/audiences"} {
+		mux.HandleFunc("POST "+prefix, s.createAudience)
+		mux.HandleFunc("GET "+prefix, s.listAudiences)
+		mux.HandleFunc("GET "+prefix+"/{id}", s.getAudience)
+		mux.HandleFunc("DELETE "+prefix+"/{id}", s.removeAudience)
+	}
+
+	mux.HandleFunc("POST /audiences/{audience_id}/contacts", s.createContact)
+	mux.HandleFunc("GET /audiences/{audience_id}/contacts", s.listContacts)
+	mux.HandleFunc("GET /audiences/{audience_id}/contacts/{id}", s.getContact)
+	mux.HandleFunc("PATCH /audiences/{audience_id}/contacts/{id}", s.updateContact)
+	mux.HandleFunc("DELETE /audiences/{audience_id}/contacts/{id}", s.removeContact)
+
+	mux.HandleFunc("POST /broadcasts", s.createBroadcast)
+	mux.HandleFunc("GET /broadcasts", s.listBroadcasts)
+	mux.HandleFunc("GET /broadcasts/{id}", s.getBroadcast)
+	mux.HandleFunc("PATCH /broadcasts/{id}", s.updateBroadcast)
+	mux.HandleFunc("DELETE /broadcasts/{id}", s.removeBroadcast)
+	mux.HandleFunc("POST /broadcasts/{id}/send", s.sendBroadcast)
+
+	mux.HandleFunc("POST /webhooks", s.createWebhook)
+	mux.HandleFunc("GET /webhooks", s.listWebhooks)
+	mux.HandleFunc("GET /webhooks/{id}", s.getWebhook)
+	mux.HandleFunc("PATCH /webhooks/{id}", s.updateWebhook)
+	mux.HandleFunc("DELETE /webhooks/{id}", s.removeWebhook)
+
+	mux.HandleFunc("POST /templates", s.createTemplate)
+	mux.HandleFunc("GET /templates", s.listTemplates)
+	mux.HandleFunc("GET /templates/{id}", s.getTemplate)
+	mux.HandleFunc("PATCH /templates/{id}", s.updateTemplate)
+	mux.HandleFunc("DELETE /templates/{id}", s.removeTemplate)
+	mux.HandleFunc("POST /templates/{id}/publish", s.publishTemplate)
+
+	s.server = httptest.NewServer(s.middleware(mux))
+	s.URL = s.server.URL + "/"
+
+	return s
+}
+
+// Close shuts the fake down.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// InjectError makes the next count requests with the given method and path
+// fail with status. The path is matched without the query string, e.g.
+// "/domains" or "/domains/<id>".
+func (s *Server) InjectError(method, path string, status, count int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.errors = append(s.errors, &injectedError{
+		Method:  method,
+		Path:    path,
+		Status:  status,
+		Message: fmt.Sprintf("injected %s error", http.StatusText(status)),
+		Count:   count,
+	})
+}
+
+// Requests returns how many requests with the given method and path the fake
+// has received, including requests that failed.
+func (s *Server) Requests(method, path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.requests[method+" "+path]
+}
+
+// middleware authenticates requests, counts them and returns injected errors.
+func (s *Server) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests[r.Method+" "+r.URL.Path]++
+		injected := s.popError(r.Method, r.URL.Path)
+		s.mu.Unlock()
+
+		if injected != nil {
+			if injected.Status == http.StatusTooManyRequests {
+				w.Header().Set("Retry-After", "1")
+			}
+			writeError(w, injected.Status, errorName(injected.Status), injected.Message)
+			return
+		}
+
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			writeError(w, http.StatusUnauthorized, "missing_api_key", "Missing API key in the authorization header.")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// popError returns the first injected error matching the request and counts
+// it down. s.mu must be held.
+func (s *Server) popError(method, path string) *injectedError {
+	for i, e := range s.errors {
+		if e.Method != method || e.Path != path {
+			continue
+		}
+		e.Count--
+		if e.Count <= 0 {
+			s.errors = append(s.errors[:i], s.errors[i+1:]...)
+		}
+		return e
+	}
+
+	return nil
+}
+
+// newId returns a random UUID. s.mu must be held.
+func (s *Server) newId() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	h := hex.EncodeToString(b)
+
+	return h[0:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:32]
+}
+
+// nextSequence returns an increasing number that orders objects by creation.
+// s.mu must be held.
+func (s *Server) nextSequence() int {
+	s.sequence++
+	return s.sequence
+}
+
+func now() string {
+	return time.Now().UTC().Format("2006-01-02T15:04:05.000000+00:00")
+}
+
+// page applies the limit and after query parameters to ids, which must be
+// sorted newest first, and reports whether there are more results.
+func page(r *http.Request, ids []string) ([]string, bool, error) {
+	query := r.URL.Query()
+
+	if after := query.Get("after"); after != "" {
+		i := indexOf(ids, after)
+		if i < 0 {
+			return nil, false, fmt.Errorf("unknown cursor %q", after)
+		}
+		ids = ids[i+1:]
+	}
+
+	limit := query.Get("limit")
+	if limit == "" {
+		return ids, false, nil
+	}
+	n, err := strconv.Atoi(limit)
+	if err != nil || n < 1 || n > 100 {
+		return nil, false, fmt.Errorf("limit must be between 1 and 100, got %q", limit)
+	}
+	if len(ids) <= n {
+		return ids, false, nil
+	}
+
+	return ids[:n], true, nil
+}
+
+// sortedIds returns the keys of objects ordered newest first.
+func sortedIds[T any](objects map[string]T, sequence func(T) int) []string {
+	ids := make([]string, 0, len(objects))
+	for id := range objects {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return sequence(objects[ids[i]]) > sequence(objects[ids[j]])
+	})
+
+	return ids
+}
+
+func indexOf(ids []string, id string) int {
+	for i, v := range ids {
+		if v == id {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// list is the envelope of every list response.
+type list[T any] struct {
+	Object  string `json:"object"`
+	Data    []T    `json:"data"`
+	HasMore bool   `json:"has_more"`
+}
+
+// deleted is the response of most delete endpoints.
+type deleted struct {
+	Object  string `json:"object"`
+	Id      string `json:"id"`
+	Deleted bool   `json:"deleted"`
+}
+
+// reference is the response of most update endpoints.
+type reference struct {
+	Object string `json:"object"`
+	Id     string `json:"id"`
+}
+
+func decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, "validation_error", fmt.Sprintf("Invalid request body: %s", err))
+		return false
+	}
+
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, name, message string) {
+	writeJSON(w, status, map[string]interface{}{
+		"statusCode": status,
+		"name":       name,
+		"message":    message,
+	})
+}
+
+func writeNotFound(w http.ResponseWriter, object string) {
+	writeError(w, http.StatusNotFound, "not_found", object+" not found")
+}
+
+func errorName(status int) string {
+	switch status {
+	case http.StatusTooManyRequests:
+		return "rate_limit_exceeded"
+	case http.StatusNotFound:
+		return "not_found"
+	case http.StatusUnprocessableEntity, http.StatusBadRequest:
+		return "validation_error"
+	}
+
+	return "internal_server_error"
+}
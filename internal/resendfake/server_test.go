@@ -0,0 +1,254 @@
+package resendfake
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/resend/resend-go/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(t *testing.T) (*Server, *resend.Client) {
+	t.Helper()
+
+	server := NewServer()
+	t.Cleanup(server.Close)
+
+	client := resend.NewClient("re_test")
+	client.BaseURL.Scheme = "http"
+	client.BaseURL.Host = server.server.Listener.Addr().String()
+
+	return server, client
+}
+
+func TestDomains(t *testing.T) {
+	server, client := newTestClient(t)
+
+	created, err := client.Domains.Create(&resend.CreateDomainRequest{Name: "example.com", Region: "eu-west-1"})
+	require.NoError(t, err)
+	require.Len(t, created.Records, 3)
+
+	_, err = client.Domains.Create(&resend.CreateDomainRequest{Name: "example.com"})
+	require.Error(t, err)
+
+	_, err = client.Domains.Verify(created.Id)
+	require.NoError(t, err)
+
+	domain, err := client.Domains.Get(created.Id)
+	require.NoError(t, err)
+	require.Equal(t, "verified", domain.Status)
+	require.Equal(t, "eu-west-1", domain.Region)
+
+	_, err = client.Domains.Update(created.Id, &resend.UpdateDomainRequest{OpenTracking: true, Tls: resend.Enforced})
+	require.NoError(t, err)
+	stored, ok := server.Domain(created.Id)
+	require.True(t, ok)
+	require.True(t, stored.OpenTracking)
+	require.Equal(t, "enforced", stored.Tls)
+
+	_, err = client.Domains.Remove(created.Id)
+	require.NoError(t, err)
+
+	_, err = client.Domains.Get(created.Id)
+	require.ErrorContains(t, err, "not found")
+}
+
+func TestApiKeysPagination(t *testing.T) {
+	_, client := newTestClient(t)
+
+	for _, name := range []string{"a", "b", "c"} {
+		_, err := client.ApiKeys.Create(&resend.CreateApiKeyRequest{Name: name})
+		require.NoError(t, err)
+	}
+
+	limit := 2
+	first, err := client.ApiKeys.ListWithOptions(context.Background(), &resend.ListOptions{Limit: &limit})
+	require.NoError(t, err)
+	require.True(t, first.HasMore)
+	require.Equal(t, []string{"c", "b"}, []string{first.Data[0].Name, first.Data[1].Name})
+
+	after := first.Data[1].Id
+	second, err := client.ApiKeys.ListWithOptions(context.Background(), &resend.ListOptions{Limit: &limit, After: &after})
+	require.NoError(t, err)
+	require.False(t, second.HasMore)
+	require.Len(t, second.Data, 1)
+	require.Equal(t, "a", second.Data[0].Name)
+}
+
+func TestAudiences(t *testing.T) {
+	server, client := newTestClient(t)
+
+	created, err := client.Segments.Create(&resend.CreateSegmentRequest{Name: "Newsletter"})
+	require.NoError(t, err)
+
+	segment, err := client.Segments.Get(created.Id)
+	require.NoError(t, err)
+	require.Equal(t, "Newsletter", segment.Name)
+	require.NotEmpty(t, segment.CreatedAt)
+
+	_, ok := server.Audience(created.Id)
+	require.True(t, ok)
+
+	_, err = client.Segments.Remove(created.Id)
+	require.NoError(t, err)
+
+	_, err = client.Segments.Get(created.Id)
+	require.ErrorContains(t, err, "not found")
+}
+
+func TestContacts(t *testing.T) {
+	server, client := newTestClient(t)
+
+	audience, err := client.Segments.Create(&resend.CreateSegmentRequest{Name: "Contacts"})
+	require.NoError(t, err)
+
+	created, err := client.Contacts.Create(&resend.CreateContactRequest{
+		AudienceId: audience.Id,
+		Email:      "jane@example.com",
+		FirstName:  "Jane",
+	})
+	require.NoError(t, err)
+
+	update := &resend.UpdateContactRequest{AudienceId: audience.Id, Id: created.Id, LastName: "Doe"}
+	update.SetUnsubscribed(true)
+	_, err = client.Contacts.Update(update)
+	require.NoError(t, err)
+
+	// Contacts can be read by their email.
+	contact, err := client.Contacts.Get(&resend.GetContactOptions{AudienceId: audience.Id, Id: "jane@example.com"})
+	require.NoError(t, err)
+	require.Equal(t, created.Id, contact.Id)
+	require.Equal(t, "Jane", contact.FirstName)
+	require.Equal(t, "Doe", contact.LastName)
+	require.True(t, contact.Unsubscribed)
+
+	_, err = client.Contacts.Create(&resend.CreateContactRequest{AudienceId: audience.Id, Email: "JANE@example.com"})
+	require.ErrorContains(t, err, "already exists")
+
+	// Removing the audience removes its contacts.
+	_, err = client.Segments.Remove(audience.Id)
+	require.NoError(t, err)
+
+	_, ok := server.Contact(audience.Id, created.Id)
+	require.False(t, ok)
+}
+
+func TestBroadcasts(t *testing.T) {
+	_, client := newTestClient(t)
+
+	audience, err := client.Segments.Create(&resend.CreateSegmentRequest{Name: "Broadcasts"})
+	require.NoError(t, err)
+
+	created, err := client.Broadcasts.Create(&resend.CreateBroadcastRequest{
+		SegmentId: audience.Id,
+		From:      "news@example.com",
+		Subject:   "Hello",
+		Html:      "<p>Hello</p>",
+	})
+	require.NoError(t, err)
+
+	_, err = client.Broadcasts.Update(&resend.UpdateBroadcastRequest{BroadcastId: created.Id, Subject: "Hello again"})
+	require.NoError(t, err)
+
+	_, err = client.Broadcasts.Send(&resend.SendBroadcastRequest{BroadcastId: created.Id})
+	require.NoError(t, err)
+
+	// The first read after sending finishes it.
+	broadcast, err := client.Broadcasts.Get(created.Id)
+	require.NoError(t, err)
+	require.Equal(t, "Hello again", broadcast.Subject)
+	require.Equal(t, "sent", broadcast.Status)
+
+	_, err = client.Broadcasts.Update(&resend.UpdateBroadcastRequest{BroadcastId: created.Id, Subject: "Too late"})
+	require.ErrorContains(t, err, "draft")
+
+	_, err = client.Broadcasts.Remove(created.Id)
+	require.ErrorContains(t, err, "draft or scheduled")
+}
+
+func TestWebhooks(t *testing.T) {
+	_, client := newTestClient(t)
+
+	created, err := client.Webhooks.Create(&resend.CreateWebhookRequest{
+		Endpoint: "https://example.com/webhooks/resend",
+		Events:   []string{resend.EventEmailBounced},
+	})
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(created.SigningSecret, "whsec_"))
+
+	status := "disabled"
+	_, err = client.Webhooks.Update(created.Id, &resend.UpdateWebhookRequest{Status: &status})
+	require.NoError(t, err)
+
+	webhook, err := client.Webhooks.Get(created.Id)
+	require.NoError(t, err)
+	require.Equal(t, "disabled", webhook.Status)
+	require.Equal(t, []string{resend.EventEmailBounced}, webhook.Events)
+	require.Equal(t, created.SigningSecret, webhook.SigningSecret)
+
+	_, err = client.Webhooks.Create(&resend.CreateWebhookRequest{
+		Endpoint: "https://example.com/webhooks/resend",
+		Events:   []string{"email.unknown"},
+	})
+	require.ErrorContains(t, err, "Invalid event")
+}
+
+func TestTemplates(t *testing.T) {
+	_, client := newTestClient(t)
+
+	created, err := client.Templates.Create(&resend.CreateTemplateRequest{
+		Name:    "welcome",
+		Alias:   "welcome",
+		Subject: "Welcome",
+		Html:    "<p>Hi {{{NAME}}}</p>",
+		Variables: []*resend.TemplateVariable{
+			{Key: "NAME", Type: resend.VariableTypeString, FallbackValue: "there"},
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = client.Templates.Publish(created.Id)
+	require.NoError(t, err)
+
+	// Templates can be read by their alias.
+	template, err := client.Templates.Get("welcome")
+	require.NoError(t, err)
+	require.Equal(t, created.Id, template.Id)
+	require.Equal(t, "published", template.Status)
+	require.Len(t, template.Variables, 1)
+	require.Equal(t, "there", template.Variables[0].FallbackValue)
+
+	_, err = client.Templates.Update(created.Id, &resend.UpdateTemplateRequest{Name: "welcome", Html: "<p>Hello</p>"})
+	require.NoError(t, err)
+
+	template, err = client.Templates.Get(created.Id)
+	require.NoError(t, err)
+	require.Equal(t, "<p>Hello</p>", template.Html)
+	require.Equal(t, "Welcome", template.Subject)
+}
+
+func TestInjectError(t *testing.T) {
+	server, client := newTestClient(t)
+
+	server.InjectError(http.MethodGet, "/api-keys", http.StatusInternalServerError, 2)
+
+	_, err := client.ApiKeys.List()
+	require.ErrorContains(t, err, "injected")
+	_, err = client.ApiKeys.List()
+	require.ErrorContains(t, err, "injected")
+	_, err = client.ApiKeys.List()
+	require.NoError(t, err)
+
+	require.Equal(t, 3, server.Requests(http.MethodGet, "/api-keys"))
+}
+
+func TestUnauthorized(t *testing.T) {
+	server, _ := newTestClient(t)
+
+	resp, err := http.Get(server.URL + "domains")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
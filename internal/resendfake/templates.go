@@ -0,0 +1,266 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resendfake
+
+import (
+	"net/http"
+)
+
+const (
+	templateStatusDraft     = "draft"
+	templateStatusPublished = "published"
+)
+
+// Template is a template stored in the fake.
+type Template struct {
+	sequence int
+
+	Id          string             `json:"id"`
+	Object      string             `json:"object"`
+	Alias       string             `json:"alias"`
+	Name        string             `json:"name"`
+	CreatedAt   string             `json:"created_at"`
+	UpdatedAt   string             `json:"updated_at"`
+	Status      string             `json:"status"`
+	PublishedAt *string            `json:"published_at"`
+	Subject     string             `json:"subject"`
+	Html        string             `json:"html"`
+	Text        string             `json:"text"`
+	Variables   []TemplateVariable `json:"variables"`
+}
+
+// TemplateVariable is a variable of a template.
+type TemplateVariable struct {
+	Id            string      `json:"id"`
+	Key           string      `json:"key"`
+	Type          string      `json:"type"`
+	FallbackValue interface{} `json:"fallback_value"`
+	CreatedAt     string      `json:"created_at"`
+	UpdatedAt     string      `json:"updated_at"`
+}
+
+// Template returns a copy of the template with the given ID.
+func (s *Server) Template(id string) (Template, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.templates[id]
+	if !ok {
+		return Template{}, false
+	}
+
+	return *t, true
+}
+
+// findTemplate returns the template with the given ID or alias, or nil. s.mu
+// must be held.
+func (s *Server) findTemplate(idOrAlias string) *Template {
+	if t, ok := s.templates[idOrAlias]; ok {
+		return t
+	}
+	for _, t := range s.templates {
+		if t.Alias != "" && t.Alias == idOrAlias {
+			return t
+		}
+	}
+
+	return nil
+}
+
+// templateParams are the attributes of a template that can be created and
+// updated. Attributes that are not sent are left unchanged by an update.
+type templateParams struct {
+	Name      *string `json:"name"`
+	Alias     *string `json:"alias"`
+	Subject   *string `json:"subject"`
+	Html      *string `json:"html"`
+	Text      *string `json:"text"`
+	Variables *[]struct {
+		Key           string      `json:"key"`
+		Type          string      `json:"type"`
+		FallbackValue interface{} `json:"fallback_value"`
+	} `json:"variables"`
+}
+
+// applyTemplateParams sets the attributes of t that are set in p. It writes
+// an error and returns false if a variable is invalid. s.mu must be held.
+func (s *Server) applyTemplateParams(w http.ResponseWriter, t *Template, p templateParams) bool {
+	var variables []TemplateVariable
+	if p.Variables != nil {
+		variables = []TemplateVariable{}
+		for _, v := range *p.Variables {
+			switch v.Type {
+			case "string", "number":
+			default:
+				writeError(w, http.StatusUnprocessableEntity, "validation_error", "Invalid type of variable `"+v.Key+"`.")
+				return false
+			}
+			if v.Key == "" {
+				writeError(w, http.StatusUnprocessableEntity, "validation_error", "Missing `key` of variable.")
+				return false
+			}
+			variables = append(variables, TemplateVariable{
+				Id:            s.newId(),
+				Key:           v.Key,
+				Type:          v.Type,
+				FallbackValue: v.FallbackValue,
+				CreatedAt:     now(),
+				UpdatedAt:     now(),
+			})
+		}
+	}
+
+	set := func(field *string, value *string) {
+		if value != nil {
+			*field = *value
+		}
+	}
+	set(&t.Name, p.Name)
+	set(&t.Alias, p.Alias)
+	set(&t.Subject, p.Subject)
+	set(&t.Html, p.Html)
+	set(&t.Text, p.Text)
+	if variables != nil {
+		t.Variables = variables
+	}
+	t.UpdatedAt = now()
+
+	return true
+}
+
+func (s *Server) createTemplate(w http.ResponseWriter, r *http.Request) {
+	var params templateParams
+	if !decode(w, r, &params) {
+		return
+	}
+
+	if params.Name == nil || *params.Name == "" {
+		writeError(w, http.StatusUnprocessableEntity, "missing_required_field", "Missing `name` field.")
+		return
+	}
+	if params.Html == nil || *params.Html == "" {
+		writeError(w, http.StatusUnprocessableEntity, "missing_required_field", "Missing `html` field.")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t := &Template{
+		sequence:  s.nextSequence(),
+		Id:        s.newId(),
+		Object:    "template",
+		CreatedAt: now(),
+		Status:    templateStatusDraft,
+		Variables: []TemplateVariable{},
+	}
+	if !s.applyTemplateParams(w, t, params) {
+		return
+	}
+	s.templates[t.Id] = t
+
+	writeJSON(w, http.StatusCreated, reference{Object: t.Object, Id: t.Id})
+}
+
+func (s *Server) listTemplates(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids, hasMore, err := page(r, sortedIds(s.templates, func(t *Template) int { return t.sequence }))
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, "validation_error", err.Error())
+		return
+	}
+
+	type item struct {
+		Id          string  `json:"id"`
+		Name        string  `json:"name"`
+		Status      string  `json:"status"`
+		PublishedAt *string `json:"published_at"`
+		CreatedAt   string  `json:"created_at"`
+		UpdatedAt   string  `json:"updated_at"`
+		Alias       string  `json:"alias"`
+	}
+	resp := list[item]{Object: "list", Data: []item{}, HasMore: hasMore}
+	for _, id := range ids {
+		t := s.templates[id]
+		resp.Data = append(resp.Data, item{
+			Id:          t.Id,
+			Name:        t.Name,
+			Status:      t.Status,
+			PublishedAt: t.PublishedAt,
+			CreatedAt:   t.CreatedAt,
+			UpdatedAt:   t.UpdatedAt,
+			Alias:       t.Alias,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) getTemplate(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t := s.findTemplate(r.PathValue("id"))
+	if t == nil {
+		writeNotFound(w, "Template")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, t)
+}
+
+func (s *Server) updateTemplate(w http.ResponseWriter, r *http.Request) {
+	var params templateParams
+	if !decode(w, r, &params) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t := s.findTemplate(r.PathValue("id"))
+	if t == nil {
+		writeNotFound(w, "Template")
+		return
+	}
+
+	if !s.applyTemplateParams(w, t, params) {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, reference{Object: t.Object, Id: t.Id})
+}
+
+func (s *Server) publishTemplate(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t := s.findTemplate(r.PathValue("id"))
+	if t == nil {
+		writeNotFound(w, "Template")
+		return
+	}
+
+	publishedAt := now()
+	t.Status = templateStatusPublished
+	t.PublishedAt = &publishedAt
+
+	writeJSON(w, http.StatusOK, reference{Object: t.Object, Id: t.Id})
+}
+
+func (s *Server) removeTemplate(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t := s.findTemplate(r.PathValue("id"))
+	if t == nil {
+		writeNotFound(w, "Template")
+		return
+	}
+	delete(s.templates, t.Id)
+
+	writeJSON(w, http.StatusOK, deleted{Object: t.Object, Id: t.Id, Deleted: true})
+}
@@ -0,0 +1,211 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resendfake
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"net/http"
+	"net/url"
+)
+
+// webhookEvents are the event types webhooks can subscribe to.
+var webhookEvents = map[string]bool{
+	"email.sent":             true,
+	"email.delivered":        true,
+	"email.delivery_delayed": true,
+	"email.complained":       true,
+	"email.bounced":          true,
+	"email.opened":           true,
+	"email.clicked":          true,
+	"email.received":         true,
+	"email.failed":           true,
+	"contact.created":        true,
+	"contact.updated":        true,
+	"contact.deleted":        true,
+	"domain.created":         true,
+	"domain.updated":         true,
+	"domain.deleted":         true,
+}
+
+// Webhook is a webhook stored in the fake.
+type Webhook struct {
+	sequence int
+
+	Id            string   `json:"id"`
+	Object        string   `json:"object"`
+	CreatedAt     string   `json:"created_at"`
+	Status        string   `json:"status"`
+	Endpoint      string   `json:"endpoint"`
+	Events        []string `json:"events"`
+	SigningSecret string   `json:"signing_secret"`
+}
+
+// Webhook returns a copy of the webhook with the given ID.
+func (s *Server) Webhook(id string) (Webhook, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	h, ok := s.webhooks[id]
+	if !ok {
+		return Webhook{}, false
+	}
+
+	return *h, true
+}
+
+// validWebhook writes an error and returns false if the endpoint is not an
+// absolute URL or an event is unknown.
+func validWebhook(w http.ResponseWriter, endpoint *string, events []string) bool {
+	if endpoint != nil {
+		u, err := url.Parse(*endpoint)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			writeError(w, http.StatusUnprocessableEntity, "validation_error", "Invalid `endpoint` field.")
+			return false
+		}
+	}
+	for _, event := range events {
+		if !webhookEvents[event] {
+			writeError(w, http.StatusUnprocessableEntity, "validation_error", "Invalid event `"+event+"`.")
+			return false
+		}
+	}
+
+	return true
+}
+
+func (s *Server) createWebhook(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Endpoint string   `json:"endpoint"`
+		Events   []string `json:"events"`
+	}
+	if !decode(w, r, &params) {
+		return
+	}
+
+	if params.Endpoint == "" {
+		writeError(w, http.StatusUnprocessableEntity, "missing_required_field", "Missing `endpoint` field.")
+		return
+	}
+	if len(params.Events) == 0 {
+		writeError(w, http.StatusUnprocessableEntity, "missing_required_field", "Missing `events` field.")
+		return
+	}
+	if !validWebhook(w, &params.Endpoint, params.Events) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	secret := make([]byte, 24)
+	_, _ = rand.Read(secret)
+
+	h := &Webhook{
+		sequence:      s.nextSequence(),
+		Id:            s.newId(),
+		Object:        "webhook",
+		CreatedAt:     now(),
+		Status:        "enabled",
+		Endpoint:      params.Endpoint,
+		Events:        params.Events,
+		SigningSecret: "whsec_" + base64.StdEncoding.EncodeToString(secret),
+	}
+	s.webhooks[h.Id] = h
+
+	writeJSON(w, http.StatusCreated, map[string]string{
+		"object":         h.Object,
+		"id":             h.Id,
+		"signing_secret": h.SigningSecret,
+	})
+}
+
+func (s *Server) listWebhooks(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids, hasMore, err := page(r, sortedIds(s.webhooks, func(h *Webhook) int { return h.sequence }))
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, "validation_error", err.Error())
+		return
+	}
+
+	resp := list[Webhook]{Object: "list", Data: []Webhook{}, HasMore: hasMore}
+	for _, id := range ids {
+		// The list endpoint does not include the signing secret.
+		h := *s.webhooks[id]
+		h.SigningSecret = ""
+		resp.Data = append(resp.Data, h)
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) getWebhook(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	h, ok := s.webhooks[r.PathValue("id")]
+	if !ok {
+		writeNotFound(w, "Webhook")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, h)
+}
+
+func (s *Server) updateWebhook(w http.ResponseWriter, r *http.Request) {
+	// Fields that are not sent are left unchanged.
+	var params struct {
+		Endpoint *string  `json:"endpoint"`
+		Events   []string `json:"events"`
+		Status   *string  `json:"status"`
+	}
+	if !decode(w, r, &params) {
+		return
+	}
+
+	if !validWebhook(w, params.Endpoint, params.Events) {
+		return
+	}
+	if params.Status != nil && *params.Status != "enabled" && *params.Status != "disabled" {
+		writeError(w, http.StatusUnprocessableEntity, "validation_error", "Invalid `status` field.")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	h, ok := s.webhooks[r.PathValue("id")]
+	if !ok {
+		writeNotFound(w, "Webhook")
+		return
+	}
+
+	if params.Endpoint != nil {
+		h.Endpoint = *params.Endpoint
+	}
+	if len(params.Events) > 0 {
+		h.Events = params.Events
+	}
+	if params.Status != nil {
+		h.Status = *params.Status
+	}
+
+	writeJSON(w, http.StatusOK, reference{Object: h.Object, Id: h.Id})
+}
+
+func (s *Server) removeWebhook(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	if _, ok := s.webhooks[id]; !ok {
+		writeNotFound(w, "Webhook")
+		return
+	}
+	delete(s.webhooks, id)
+
+	writeJSON(w, http.StatusOK, deleted{Object: "webhook", Id: id, Deleted: true})
+}